@@ -0,0 +1,6 @@
+package contract
+
+// BindingSnapshotJSON returns a copy of the binding snapshot golden JSON payload.
+func BindingSnapshotJSON() []byte {
+	return fixtureBytes("binding_snapshot.json")
+}
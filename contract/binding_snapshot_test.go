@@ -0,0 +1,38 @@
+package contract
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ez-api/foundation/routing"
+)
+
+func TestBindingSnapshotGolden_IsValid(t *testing.T) {
+	var snap routing.BindingSnapshot
+	if err := json.Unmarshal(BindingSnapshotJSON(), &snap); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if snap.Namespace == "" || snap.PublicModel == "" || len(snap.Candidates) == 0 {
+		t.Fatalf("expected namespace, public_model and candidates, got %+v", snap)
+	}
+
+	selectors := map[routing.SelectorType]bool{}
+	var sawError, sawMultiUpstream bool
+	for _, c := range snap.Candidates {
+		selectors[routing.SelectorType(c.SelectorType)] = true
+		if c.Error != "" {
+			sawError = true
+		}
+		if len(c.Upstreams) > 1 {
+			sawMultiUpstream = true
+		}
+	}
+	for _, st := range []routing.SelectorType{routing.SelectorExact, routing.SelectorRegex, routing.SelectorNormalizeExact} {
+		if !selectors[st] {
+			t.Errorf("golden should cover selector type %q", st)
+		}
+	}
+	if !sawError || !sawMultiUpstream {
+		t.Errorf("golden should include an error candidate and a multi-upstream candidate")
+	}
+}
@@ -0,0 +1,25 @@
+package contract
+
+import "github.com/ez-api/foundation/jsoncodec"
+
+// ChecksumFixture is a test vector for modelcap.ChecksumFromPayloads:
+// CP and DP (and non-Go tooling) must all reproduce Checksum from Payloads.
+type ChecksumFixture struct {
+	Name     string            `json:"name"`
+	Payloads map[string]string `json:"payloads"`
+	Checksum string            `json:"checksum"`
+}
+
+// ChecksumFixturesJSON returns a copy of the checksum fixture file.
+func ChecksumFixturesJSON() []byte {
+	return fixtureBytes("checksum_fixtures.json")
+}
+
+// ChecksumFixtures returns the decoded checksum test vectors.
+func ChecksumFixtures() []ChecksumFixture {
+	var fixtures []ChecksumFixture
+	if err := jsoncodec.Unmarshal(fixtureBytes("checksum_fixtures.json"), &fixtures); err != nil {
+		panic("contract: invalid embedded checksum fixtures: " + err.Error())
+	}
+	return fixtures
+}
@@ -0,0 +1,19 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/ez-api/foundation/modelcap"
+)
+
+func TestChecksumFixtures(t *testing.T) {
+	fixtures := ChecksumFixtures()
+	if len(fixtures) == 0 {
+		t.Fatal("expected checksum fixtures")
+	}
+	for _, fx := range fixtures {
+		if got := modelcap.ChecksumFromPayloads(fx.Payloads); got != fx.Checksum {
+			t.Errorf("%s: ChecksumFromPayloads = %s, want %s", fx.Name, got, fx.Checksum)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package contract
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ez-api/foundation/jsoncodec"
+)
+
+// compatDir holds historical payloads laid out as compat/<version>/<contract>/<case>.json.
+const compatDir = testdataDir + "/compat"
+
+// CompatCorpus returns every historical payload keyed by contract name, ordered by
+// version and then file name. Downstream services can feed these to their own
+// decoders to make sure payloads written by older CP releases still load.
+func CompatCorpus() map[string][][]byte {
+	out := make(map[string][][]byte)
+	for _, f := range compatFiles() {
+		b, err := testdataFS.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		name := path.Base(path.Dir(f))
+		out[name] = append(out[name], b)
+	}
+	return out
+}
+
+// RunCompatTests decodes every corpus payload with the current structs and validates it,
+// then checks that current goldens still decode and validate when a field unknown to
+// this version is present.
+func RunCompatTests(t *testing.T) {
+	t.Helper()
+	for _, f := range compatFiles() {
+		name := path.Base(path.Dir(f))
+		rel := strings.TrimPrefix(f, compatDir+"/")
+		t.Run(rel, func(t *testing.T) {
+			validate, ok := payloadValidators[name]
+			if !ok {
+				t.Fatalf("no validator for contract %q", name)
+			}
+			b, err := testdataFS.ReadFile(f)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if err := validate(b); err != nil {
+				t.Fatalf("historical payload rejected: %v", err)
+			}
+		})
+	}
+
+	for name, golden := range currentGoldens() {
+		t.Run("forward/"+name, func(t *testing.T) {
+			var doc map[string]any
+			if err := jsoncodec.Unmarshal(golden, &doc); err != nil {
+				t.Fatalf("decode golden: %v", err)
+			}
+			doc["x_future_field"] = map[string]any{"added_by": "a newer writer"}
+			b, err := jsoncodec.Marshal(doc)
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+			if err := payloadValidators[name](b); err != nil {
+				t.Fatalf("golden with unknown field rejected: %v", err)
+			}
+		})
+	}
+}
+
+func currentGoldens() map[string][]byte {
+	return map[string][]byte{
+		ContractModel:            ModelSnapshotJSON(),
+		ContractModelsMeta:       ModelsMetaJSON(),
+		ContractBindingSnapshot:  BindingSnapshotJSON(),
+		ContractProviderSnapshot: ProviderSnapshotJSON(),
+		ContractSchedulerStatus:  SchedulerStatusJSON(),
+	}
+}
+
+func compatFiles() []string {
+	var files []string
+	_ = fs.WalkDir(testdataFS, compatDir, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(p, ".json") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,16 @@
+package contract
+
+import "testing"
+
+func TestCompatCorpus(t *testing.T) {
+	RunCompatTests(t)
+}
+
+func TestCompatCorpus_CoversEveryContract(t *testing.T) {
+	corpus := CompatCorpus()
+	for name := range payloadValidators {
+		if len(corpus[name]) == 0 {
+			t.Errorf("compat corpus has no payloads for %q", name)
+		}
+	}
+}
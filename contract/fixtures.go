@@ -0,0 +1,120 @@
+package contract
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// testdataFS holds every contract fixture; accessors and the registry read from it.
+//
+//go:embed testdata
+var testdataFS embed.FS
+
+const testdataDir = "testdata"
+
+// FixtureKind classifies a fixture by what it pins.
+type FixtureKind string
+
+const (
+	KindModel           FixtureKind = "model"
+	KindMeta            FixtureKind = "meta"
+	KindBinding         FixtureKind = "binding"
+	KindProvider        FixtureKind = "provider"
+	KindSchedulerStatus FixtureKind = "scheduler_status"
+	KindCatalog         FixtureKind = "catalog"
+	KindChecksum        FixtureKind = "checksum"
+	KindTokenHash       FixtureKind = "token_hash"
+	KindRequestID       FixtureKind = "request_id"
+	KindProviderTypes   FixtureKind = "provider_types"
+	KindGroupShard      FixtureKind = "group_shard"
+	KindSchema          FixtureKind = "schema"
+)
+
+// fixtureKinds classifies top-level fixture files.
+var fixtureKinds = map[string]FixtureKind{
+	"model_snapshot.json":      KindModel,
+	"models_meta.json":         KindMeta,
+	"binding_snapshot.json":    KindBinding,
+	"provider_snapshot.json":   KindProvider,
+	"scheduler_status.json":    KindSchedulerStatus,
+	"models_catalog.json":      KindCatalog,
+	"models_catalog_meta.json": KindMeta,
+	"checksum_fixtures.json":   KindChecksum,
+	"token_hashes.json":        KindTokenHash,
+	"request_ids.json":         KindRequestID,
+	"provider_types.json":      KindProviderTypes,
+	"group_shards.json":        KindGroupShard,
+}
+
+// contractKinds classifies payloads by contract name (used for the compat corpus).
+var contractKinds = map[string]FixtureKind{
+	ContractModel:            KindModel,
+	ContractModelsMeta:       KindMeta,
+	ContractBindingSnapshot:  KindBinding,
+	ContractProviderSnapshot: KindProvider,
+	ContractSchedulerStatus:  KindSchedulerStatus,
+}
+
+// Fixture is an embedded contract fixture. Name is its path relative to testdata,
+// e.g. "binding_snapshot.json" or "compat/v1/model/basic.json".
+type Fixture struct {
+	Name string
+	Kind FixtureKind
+	data []byte
+}
+
+// Bytes returns a copy of the fixture contents.
+func (f Fixture) Bytes() []byte {
+	return append([]byte(nil), f.data...)
+}
+
+// Fixtures returns every embedded fixture, sorted by name.
+func Fixtures() []Fixture {
+	var out []Fixture
+	_ = fs.WalkDir(testdataFS, testdataDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if f, ok := LookupFixture(strings.TrimPrefix(p, testdataDir+"/")); ok {
+			out = append(out, f)
+		}
+		return nil
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// LookupFixture returns the fixture with the given name (path relative to testdata).
+func LookupFixture(name string) (Fixture, bool) {
+	b, err := testdataFS.ReadFile(path.Join(testdataDir, name))
+	if err != nil {
+		return Fixture{}, false
+	}
+	return Fixture{Name: name, Kind: fixtureKind(name), data: b}, true
+}
+
+func fixtureKind(name string) FixtureKind {
+	if k, ok := fixtureKinds[name]; ok {
+		return k
+	}
+	if strings.HasPrefix(name, "schema/") {
+		return KindSchema
+	}
+	// compat/<version>/<contract>/<case>.json
+	if parts := strings.Split(name, "/"); len(parts) == 4 && parts[0] == "compat" {
+		return contractKinds[parts[2]]
+	}
+	return ""
+}
+
+// fixtureBytes returns a copy of an embedded fixture that is known to exist.
+func fixtureBytes(name string) []byte {
+	f, ok := LookupFixture(name)
+	if !ok {
+		panic("contract: missing embedded fixture " + name)
+	}
+	return f.data
+}
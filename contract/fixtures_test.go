@@ -0,0 +1,66 @@
+package contract
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFixtures_CoverTestdata(t *testing.T) {
+	registered := make(map[string]Fixture)
+	for _, f := range Fixtures() {
+		registered[f.Name] = f
+	}
+
+	var files int
+	err := filepath.WalkDir(testdataDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		files++
+		name, _ := filepath.Rel(testdataDir, p)
+		name = filepath.ToSlash(name)
+		f, ok := registered[name]
+		if !ok {
+			t.Errorf("%s is not reachable through Fixtures()", name)
+			return nil
+		}
+		if f.Kind == "" {
+			t.Errorf("%s has no kind; classify it in fixtureKinds", name)
+		}
+		onDisk, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(onDisk, f.Bytes()) {
+			t.Errorf("%s: embedded bytes differ from disk", name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk testdata: %v", err)
+	}
+	if files != len(registered) {
+		t.Errorf("testdata has %d files, registry has %d", files, len(registered))
+	}
+}
+
+func TestLookupFixture(t *testing.T) {
+	f, ok := LookupFixture("binding_snapshot.json")
+	if !ok || f.Kind != KindBinding || !bytes.Equal(f.Bytes(), BindingSnapshotJSON()) {
+		t.Fatalf("LookupFixture(binding_snapshot.json) = %+v, %v", f.Name, ok)
+	}
+	if f, ok := LookupFixture("compat/v1/models_meta/basic.json"); !ok || f.Kind != KindMeta {
+		t.Fatalf("compat fixture kind = %q, %v", f.Kind, ok)
+	}
+	if _, ok := LookupFixture("nope.json"); ok {
+		t.Fatal("expected missing fixture")
+	}
+	b := f.Bytes()
+	b[0] = 'x'
+	if f.Bytes()[0] == 'x' {
+		t.Fatal("Bytes must return a copy")
+	}
+}
@@ -0,0 +1,43 @@
+package contract
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ez-api/foundation/jsoncodec"
+)
+
+// UpdateGoldenEnv enables golden regeneration in MaybeUpdateGolden when set to "1".
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// CanonicalJSON returns the canonical golden encoding of v: sorted map keys,
+// two-space indentation, and a trailing newline.
+func CanonicalJSON(v any) ([]byte, error) {
+	b, err := jsoncodec.MarshalCanonical(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// WriteGolden writes the canonical encoding of v to path.
+func WriteGolden(path string, v any) error {
+	b, err := CanonicalJSON(v)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// MaybeUpdateGolden rewrites the golden at path from v when UPDATE_GOLDEN=1.
+// Otherwise it does nothing, so tests can call it unconditionally before comparing.
+func MaybeUpdateGolden(t *testing.T, path string, v any) {
+	t.Helper()
+	if os.Getenv(UpdateGoldenEnv) != "1" {
+		return
+	}
+	if err := WriteGolden(path, v); err != nil {
+		t.Fatalf("update golden %s: %v", path, err)
+	}
+	t.Logf("updated golden %s", path)
+}
@@ -0,0 +1,76 @@
+package contract
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ez-api/foundation/jsoncodec"
+	"github.com/ez-api/foundation/modelcap"
+	"github.com/ez-api/foundation/provider"
+	"github.com/ez-api/foundation/routing"
+	"github.com/ez-api/foundation/scheduler"
+)
+
+func TestGoldens_AreCanonical(t *testing.T) {
+	t.Run(ContractModel, func(t *testing.T) {
+		assertCanonicalGolden[modelcap.Model](t, "testdata/model_snapshot.json")
+	})
+	t.Run(ContractModelsMeta, func(t *testing.T) {
+		assertCanonicalGolden[modelcap.Meta](t, "testdata/models_meta.json")
+	})
+	t.Run(ContractBindingSnapshot, func(t *testing.T) {
+		assertCanonicalGolden[routing.BindingSnapshot](t, "testdata/binding_snapshot.json")
+	})
+	t.Run(ContractProviderSnapshot, func(t *testing.T) {
+		assertCanonicalGolden[provider.Snapshot](t, "testdata/provider_snapshot.json")
+	})
+	t.Run(ContractSchedulerStatus, func(t *testing.T) {
+		assertCanonicalGolden[scheduler.Status](t, "testdata/scheduler_status.json")
+	})
+}
+
+func TestWriteGolden_IsByteIdentical(t *testing.T) {
+	var snap routing.BindingSnapshot
+	if err := jsoncodec.Unmarshal(BindingSnapshotJSON(), &snap); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "binding_snapshot.json")
+	if err := WriteGolden(path, snap); err != nil {
+		t.Fatalf("WriteGolden: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !bytes.Equal(got, BindingSnapshotJSON()) {
+		t.Fatalf("regenerated golden differs from committed one:\n%s", got)
+	}
+}
+
+// assertCanonicalGolden decodes the golden at path into T, optionally regenerates it,
+// and requires the committed bytes to equal the canonical encoding.
+func assertCanonicalGolden[T any](t *testing.T, path string) {
+	t.Helper()
+	committed, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var v T
+	if err := jsoncodec.Unmarshal(committed, &v); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	MaybeUpdateGolden(t, path, v)
+
+	want, err := CanonicalJSON(v)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if os.Getenv(UpdateGoldenEnv) == "1" {
+		return
+	}
+	if !bytes.Equal(committed, want) {
+		t.Fatalf("%s is not canonical; regenerate with %s=1 go test ./contract:\n%s", path, UpdateGoldenEnv, want)
+	}
+}
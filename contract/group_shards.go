@@ -0,0 +1,34 @@
+package contract
+
+import "github.com/ez-api/foundation/jsoncodec"
+
+// GroupShardVector is a cross-service test vector for group.Shard and group.ShardKey.
+type GroupShardVector struct {
+	Name   string `json:"name"`
+	Shards int    `json:"shards"`
+	Shard  int    `json:"shard"`
+	Key    string `json:"key"` // group.ShardKey(KeyPrefix, Name, Shards)
+	Note   string `json:"note,omitempty"`
+}
+
+// GroupShardVectors is the on-disk layout of testdata/group_shards.json.
+type GroupShardVectors struct {
+	Version   int                `json:"version"`
+	Algorithm string             `json:"algorithm"`
+	KeyPrefix string             `json:"key_prefix"`
+	Vectors   []GroupShardVector `json:"vectors"`
+}
+
+// GroupShardsJSON returns a copy of the group shard vector file.
+func GroupShardsJSON() []byte {
+	return fixtureBytes("group_shards.json")
+}
+
+// GroupShards returns the decoded group shard vectors.
+func GroupShards() GroupShardVectors {
+	var v GroupShardVectors
+	if err := jsoncodec.Unmarshal(fixtureBytes("group_shards.json"), &v); err != nil {
+		panic("contract: invalid embedded group shard vectors: " + err.Error())
+	}
+	return v
+}
@@ -0,0 +1,26 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/ez-api/foundation/group"
+)
+
+func TestGroupShardVectors(t *testing.T) {
+	vectors := GroupShards()
+	if len(vectors.Vectors) == 0 {
+		t.Fatal("expected group shard vectors")
+	}
+	for _, v := range vectors.Vectors {
+		got, err := group.Shard(v.Name, v.Shards)
+		if err != nil {
+			t.Fatalf("Shard(%q, %d): %v", v.Name, v.Shards, err)
+		}
+		if got != v.Shard {
+			t.Errorf("Shard(%q, %d) = %d, want %d", v.Name, v.Shards, got, v.Shard)
+		}
+		if key := group.ShardKey(vectors.KeyPrefix, v.Name, v.Shards); key != v.Key {
+			t.Errorf("ShardKey(%q, %q, %d) = %q, want %q", vectors.KeyPrefix, v.Name, v.Shards, key, v.Key)
+		}
+	}
+}
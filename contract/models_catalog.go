@@ -0,0 +1,39 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ez-api/foundation/jsoncodec"
+)
+
+// ModelsCatalogJSON returns a copy of the models catalog golden: an object mirroring the
+// Redis meta:models hash, mapping bindingKey to the model payload.
+func ModelsCatalogJSON() []byte {
+	return fixtureBytes("models_catalog.json")
+}
+
+// ModelsCatalogMetaJSON returns a copy of the models_meta golden describing ModelsCatalogJSON,
+// including its checksum.
+func ModelsCatalogMetaJSON() []byte {
+	return fixtureBytes("models_catalog_meta.json")
+}
+
+// CatalogPayloads converts a catalog document into the hash field view stored in Redis:
+// bindingKey -> compact model JSON. This is the input to modelcap.ChecksumFromPayloads.
+func CatalogPayloads(catalog []byte) (map[string]string, error) {
+	var entries map[string]json.RawMessage
+	if err := jsoncodec.Unmarshal(catalog, &entries); err != nil {
+		return nil, fmt.Errorf("decode catalog: %w", err)
+	}
+	out := make(map[string]string, len(entries))
+	for key, raw := range entries {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, raw); err != nil {
+			return nil, fmt.Errorf("entry %q: %w", key, err)
+		}
+		out[key] = buf.String()
+	}
+	return out, nil
+}
@@ -0,0 +1,69 @@
+package contract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ez-api/foundation/jsoncodec"
+	"github.com/ez-api/foundation/modelcap"
+	"github.com/ez-api/foundation/routing"
+)
+
+func TestModelsCatalogGolden(t *testing.T) {
+	payloads, err := CatalogPayloads(ModelsCatalogJSON())
+	if err != nil {
+		t.Fatalf("CatalogPayloads: %v", err)
+	}
+	if len(payloads) < 2 {
+		t.Fatalf("expected several catalog entries, got %d", len(payloads))
+	}
+
+	var sawMultiDot bool
+	for key, payload := range payloads {
+		ref, err := routing.ParseBindingKey(key)
+		if err != nil {
+			t.Errorf("key %q: %v", key, err)
+			continue
+		}
+		if strings.Contains(ref.PublicModel, ".") {
+			sawMultiDot = true
+		}
+		if err := ValidateModelPayload([]byte(payload)); err != nil {
+			t.Errorf("key %q: %v", key, err)
+			continue
+		}
+		var m modelcap.Model
+		if err := jsoncodec.UnmarshalString(payload, &m); err != nil {
+			t.Fatalf("key %q: %v", key, err)
+		}
+		if m.Name != key {
+			t.Errorf("key %q: model name %q does not match its bindingKey", key, m.Name)
+		}
+	}
+	if !sawMultiDot {
+		t.Error("catalog should include a public model containing dots")
+	}
+
+	var meta modelcap.Meta
+	if err := jsoncodec.Unmarshal(ModelsCatalogMetaJSON(), &meta); err != nil {
+		t.Fatalf("unmarshal meta: %v", err)
+	}
+	if err := meta.Validate(); err != nil {
+		t.Fatalf("meta: %v", err)
+	}
+	if got := modelcap.ChecksumFromPayloads(payloads); got != meta.Checksum {
+		t.Fatalf("catalog checksum = %s, meta records %s", got, meta.Checksum)
+	}
+}
+
+func TestParseBindingKey(t *testing.T) {
+	ref, err := routing.ParseBindingKey("default.gpt-4o.mini")
+	if err != nil || ref.Namespace != "default" || ref.PublicModel != "gpt-4o.mini" {
+		t.Fatalf("ParseBindingKey = %+v, %v", ref, err)
+	}
+	for _, bad := range []string{"", "nodot", ".m", "ns.", " ns.m"} {
+		if _, err := routing.ParseBindingKey(bad); err == nil {
+			t.Errorf("ParseBindingKey(%q): expected error", bad)
+		}
+	}
+}
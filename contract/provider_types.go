@@ -0,0 +1,21 @@
+package contract
+
+import (
+	"github.com/ez-api/foundation/jsoncodec"
+	"github.com/ez-api/foundation/provider"
+)
+
+// ProviderTypesJSON returns a copy of the provider type registry fixture, generated
+// from provider.AllTypes for UI pickers.
+func ProviderTypesJSON() []byte {
+	return fixtureBytes("provider_types.json")
+}
+
+// ProviderTypes returns the decoded provider type registry fixture.
+func ProviderTypes() []provider.TypeInfo {
+	var types []provider.TypeInfo
+	if err := jsoncodec.Unmarshal(fixtureBytes("provider_types.json"), &types); err != nil {
+		panic("contract: invalid embedded provider types: " + err.Error())
+	}
+	return types
+}
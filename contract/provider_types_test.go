@@ -0,0 +1,22 @@
+package contract
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/ez-api/foundation/provider"
+)
+
+func TestProviderTypesFixture_MatchesRegistry(t *testing.T) {
+	path := filepath.Join(testdataDir, "provider_types.json")
+	MaybeUpdateGolden(t, path, provider.AllTypes())
+
+	want, err := CanonicalJSON(provider.AllTypes())
+	if err != nil {
+		t.Fatalf("encode registry: %v", err)
+	}
+	if !bytes.Equal(ProviderTypesJSON(), want) {
+		t.Errorf("provider_types.json is out of date with provider.AllTypes; run with %s=1", UpdateGoldenEnv)
+	}
+}
@@ -0,0 +1,36 @@
+package contract
+
+import (
+	"fmt"
+
+	"github.com/ez-api/foundation/jsoncodec"
+	"github.com/ez-api/foundation/requestid"
+)
+
+// RequestIDFixtures lists request ids that must pass or fail ValidateRequestID.
+type RequestIDFixtures struct {
+	Valid   []string `json:"valid"`
+	Invalid []string `json:"invalid"`
+}
+
+// RequestIDsJSON returns a copy of the request id fixture file.
+func RequestIDsJSON() []byte {
+	return fixtureBytes("request_ids.json")
+}
+
+// RequestIDs returns the decoded request id fixtures.
+func RequestIDs() RequestIDFixtures {
+	var f RequestIDFixtures
+	if err := jsoncodec.Unmarshal(fixtureBytes("request_ids.json"), &f); err != nil {
+		panic("contract: invalid embedded request id fixtures: " + err.Error())
+	}
+	return f
+}
+
+// ValidateRequestID checks id against the request id format contract (see requestid.Valid).
+func ValidateRequestID(id string) error {
+	if !requestid.Valid(id) {
+		return &ValidationError{Contract: ContractRequestID, Err: fmt.Errorf("invalid request id %q: want %d lowercase hex characters", id, requestid.Len)}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/ez-api/foundation/requestid"
+)
+
+func TestRequestIDFixtures(t *testing.T) {
+	f := RequestIDs()
+	for _, id := range f.Valid {
+		if err := ValidateRequestID(id); err != nil {
+			t.Errorf("expected %q to be valid: %v", id, err)
+		}
+	}
+	for _, id := range f.Invalid {
+		if err := ValidateRequestID(id); err == nil {
+			t.Errorf("expected %q to be invalid", id)
+		}
+	}
+}
+
+func TestRequestIDNew_SatisfiesContract(t *testing.T) {
+	seen := make(map[string]struct{}, 10000)
+	for i := 0; i < 10000; i++ {
+		id := requestid.New()
+		if err := ValidateRequestID(id); err != nil {
+			t.Fatalf("generation %d: %v", i, err)
+		}
+		if _, dup := seen[id]; dup {
+			t.Fatalf("generation %d: duplicate id %s", i, id)
+		}
+		seen[id] = struct{}{}
+	}
+}
@@ -0,0 +1,37 @@
+package contract
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ez-api/foundation/jsoncodec"
+)
+
+// RoundTrip asserts that payload decodes into T, passes validate (if non-nil),
+// survives a canonical encode, and decodes again into a deeply equal value.
+func RoundTrip[T any](t *testing.T, payload []byte, validate func(T) error) {
+	t.Helper()
+
+	var first T
+	if err := jsoncodec.Unmarshal(payload, &first); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if validate != nil {
+		if err := validate(first); err != nil {
+			t.Fatalf("validate: %v", err)
+		}
+	}
+
+	encoded, err := jsoncodec.MarshalCanonicalCompact(first)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	var second T
+	if err := jsoncodec.Unmarshal(encoded, &second); err != nil {
+		t.Fatalf("decode re-encoded payload: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("round trip mismatch:\nfirst:  %+v\nsecond: %+v", first, second)
+	}
+}
@@ -0,0 +1,57 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/ez-api/foundation/jsoncodec"
+	"github.com/ez-api/foundation/modelcap"
+	"github.com/ez-api/foundation/provider"
+	"github.com/ez-api/foundation/routing"
+	"github.com/ez-api/foundation/scheduler"
+)
+
+func TestRoundTrip_Goldens(t *testing.T) {
+	t.Run(ContractModel, func(t *testing.T) {
+		RoundTrip(t, ModelSnapshotJSON(), modelcap.Model.Validate)
+	})
+	t.Run(ContractModelsMeta, func(t *testing.T) {
+		RoundTrip(t, ModelsMetaJSON(), modelcap.Meta.Validate)
+	})
+	t.Run(ContractBindingSnapshot, func(t *testing.T) {
+		RoundTrip(t, BindingSnapshotJSON(), routing.BindingSnapshot.Validate)
+	})
+	t.Run(ContractProviderSnapshot, func(t *testing.T) {
+		RoundTrip(t, ProviderSnapshotJSON(), provider.Snapshot.Validate)
+	})
+	t.Run(ContractSchedulerStatus, func(t *testing.T) {
+		RoundTrip(t, SchedulerStatusJSON(), scheduler.Status.Validate)
+	})
+}
+
+func FuzzBindingSnapshot(f *testing.F) {
+	f.Add(BindingSnapshotJSON())
+	for _, b := range CompatCorpus()[ContractBindingSnapshot] {
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		var snap routing.BindingSnapshot
+		if err := jsoncodec.Unmarshal(payload, &snap); err != nil {
+			return
+		}
+		_ = snap.Validate()
+	})
+}
+
+func FuzzModel(f *testing.F) {
+	f.Add(ModelSnapshotJSON())
+	for _, b := range CompatCorpus()[ContractModel] {
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		var m modelcap.Model
+		if err := jsoncodec.Unmarshal(payload, &m); err != nil {
+			return
+		}
+		_ = m.Validate()
+	})
+}
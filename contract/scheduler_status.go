@@ -0,0 +1,6 @@
+package contract
+
+// SchedulerStatusJSON returns a copy of the scheduler status golden JSON payload.
+func SchedulerStatusJSON() []byte {
+	return fixtureBytes("scheduler_status.json")
+}
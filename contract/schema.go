@@ -0,0 +1,221 @@
+package contract
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ez-api/foundation/jsoncodec"
+)
+
+const schemaDir = testdataDir + "/schema"
+
+// SchemaNames returns the names of the embedded JSON Schemas (the contract names, e.g. "binding_snapshot").
+func SchemaNames() []string {
+	entries, _ := testdataFS.ReadDir(schemaDir)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".schema.json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SchemaJSON returns a copy of the draft 2020-12 JSON Schema for the named contract.
+func SchemaJSON(name string) ([]byte, error) {
+	b, err := testdataFS.ReadFile(path.Join(schemaDir, name+".schema.json"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown schema %q", name)
+	}
+	return b, nil
+}
+
+// ValidateAgainstSchema validates payload against the named embedded schema.
+//
+// The validator is deliberately small: it understands the keywords our schemas use
+// (type, properties, required, additionalProperties, items, enum, minimum, maximum,
+// minLength, pattern and local "#/$defs/..." references) and ignores the rest.
+func ValidateAgainstSchema(schemaName string, payload []byte) error {
+	raw, err := SchemaJSON(schemaName)
+	if err != nil {
+		return err
+	}
+	var schema map[string]any
+	if err := jsoncodec.Unmarshal(raw, &schema); err != nil {
+		return fmt.Errorf("schema %s: %w", schemaName, err)
+	}
+	var doc any
+	if err := jsoncodec.Unmarshal(payload, &doc); err != nil {
+		return &ValidationError{Contract: schemaName, Err: fmt.Errorf("decode: %w", err)}
+	}
+	v := schemaValidator{root: schema}
+	if err := v.validate(schema, doc, "$"); err != nil {
+		return &ValidationError{Contract: schemaName, Err: err}
+	}
+	return nil
+}
+
+type schemaValidator struct {
+	root map[string]any
+}
+
+func (v schemaValidator) validate(schema map[string]any, doc any, at string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, err := v.resolve(ref)
+		if err != nil {
+			return err
+		}
+		schema = resolved
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, doc) {
+		return fmt.Errorf("%s: expected type %v, got %s", at, t, jsonType(doc))
+	}
+	if enum, ok := schema["enum"].([]any); ok && !inEnum(enum, doc) {
+		return fmt.Errorf("%s: value %v not in enum %v", at, doc, enum)
+	}
+
+	switch d := doc.(type) {
+	case map[string]any:
+		return v.validateObject(schema, d, at)
+	case []any:
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range d {
+			if err := v.validate(items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case string:
+		if min, ok := schema["minLength"].(float64); ok && float64(len([]rune(d))) < min {
+			return fmt.Errorf("%s: shorter than %v", at, min)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("%s: invalid pattern: %w", at, err)
+			}
+			if !re.MatchString(d) {
+				return fmt.Errorf("%s: does not match %q", at, pattern)
+			}
+		}
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok && d < min {
+			return fmt.Errorf("%s: %v is less than minimum %v", at, d, min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && d > max {
+			return fmt.Errorf("%s: %v is greater than maximum %v", at, d, max)
+		}
+	}
+	return nil
+}
+
+func (v schemaValidator) validateObject(schema map[string]any, obj map[string]any, at string) error {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			key, _ := r.(string)
+			if _, ok := obj[key]; !ok {
+				return fmt.Errorf("%s: missing required property %q", at, key)
+			}
+		}
+	}
+	props, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sub, ok := props[k].(map[string]any)
+		if !ok {
+			switch ap := schema["additionalProperties"].(type) {
+			case bool:
+				if !ap {
+					return fmt.Errorf("%s: unexpected property %q", at, k)
+				}
+				continue
+			case map[string]any:
+				sub = ap
+			default:
+				continue
+			}
+		}
+		if err := v.validate(sub, obj[k], at+"."+k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v schemaValidator) resolve(ref string) (map[string]any, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q", ref)
+	}
+	defs, _ := v.root["$defs"].(map[string]any)
+	def, ok := defs[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unresolved $ref %q", ref)
+	}
+	return def, nil
+}
+
+func matchesType(t any, doc any) bool {
+	switch tt := t.(type) {
+	case string:
+		return isType(tt, doc)
+	case []any:
+		for _, x := range tt {
+			if s, ok := x.(string); ok && isType(s, doc) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isType(t string, doc any) bool {
+	switch t {
+	case "integer":
+		f, ok := doc.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := doc.(float64)
+		return ok
+	default:
+		return jsonType(doc) == t
+	}
+}
+
+func jsonType(doc any) string {
+	switch doc.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", doc)
+	}
+}
+
+func inEnum(enum []any, doc any) bool {
+	for _, e := range enum {
+		if e == doc {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package contract
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSchemas_AcceptGoldens(t *testing.T) {
+	goldens := currentGoldens()
+	names := SchemaNames()
+	if fmt.Sprint(names) != "[binding_snapshot model models_meta provider_snapshot scheduler_status]" {
+		t.Fatalf("unexpected schema set: %v", names)
+	}
+	for _, name := range names {
+		golden, ok := goldens[name]
+		if !ok {
+			t.Fatalf("schema %q has no golden", name)
+		}
+		if err := ValidateAgainstSchema(name, golden); err != nil {
+			t.Errorf("%s golden rejected by schema: %v", name, err)
+		}
+	}
+}
+
+func TestSchemas_RejectWrongTypes(t *testing.T) {
+	cases := []struct {
+		schema  string
+		payload []byte
+	}{
+		{ContractModel, corrupt(ModelSnapshotJSON(), `"context_window": 128000`, `"context_window": "128000"`)},
+		{ContractModel, corrupt(ModelSnapshotJSON(), `"supports_vision": true`, `"supports_vision": 1`)},
+		{ContractModelsMeta, corrupt(ModelsMetaJSON(), `"updated_at": "1734464000"`, `"updated_at": 1734464000`)},
+		{ContractBindingSnapshot, corrupt(BindingSnapshotJSON(), `"group_id": 7`, `"group_id": "7"`)},
+		{ContractBindingSnapshot, corrupt(BindingSnapshotJSON(), `"weight": 80`, `"weight": 0.5`)},
+		{ContractBindingSnapshot, corrupt(BindingSnapshotJSON(), `"timeout_ms": 30000`, `"timeout_ms": 3600000`)},
+		{ContractBindingSnapshot, corrupt(BindingSnapshotJSON(), `"selector_type": "exact"`, `"selector_type": "fuzzy"`)},
+		{ContractProviderSnapshot, corrupt(ProviderSnapshotJSON(), `"auto_ban": true`, `"auto_ban": "yes"`)},
+		{ContractProviderSnapshot, corrupt(ProviderSnapshotJSON(), `"id": 42,`, ``)},
+	}
+	for i, tc := range cases {
+		if err := ValidateAgainstSchema(tc.schema, tc.payload); err == nil {
+			t.Errorf("case %d (%s): expected schema violation", i, tc.schema)
+		}
+	}
+	if err := ValidateAgainstSchema("nope", []byte(`{}`)); err == nil {
+		t.Error("expected error for unknown schema")
+	}
+}
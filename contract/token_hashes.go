@@ -0,0 +1,31 @@
+package contract
+
+import "github.com/ez-api/foundation/jsoncodec"
+
+// TokenHashVector is a cross-service test vector for tokenhash.HashToken.
+// Hashing is over the raw token bytes: no trimming or case folding happens.
+type TokenHashVector struct {
+	Token string `json:"token"`
+	Hash  string `json:"hash"`
+	Note  string `json:"note,omitempty"`
+}
+
+// TokenHashVectors is the on-disk layout of testdata/token_hashes.json.
+type TokenHashVectors struct {
+	Version int               `json:"version"`
+	Vectors []TokenHashVector `json:"vectors"`
+}
+
+// TokenHashesJSON returns a copy of the token hash vector file.
+func TokenHashesJSON() []byte {
+	return fixtureBytes("token_hashes.json")
+}
+
+// TokenHashes returns the decoded token hash vectors.
+func TokenHashes() TokenHashVectors {
+	var v TokenHashVectors
+	if err := jsoncodec.Unmarshal(fixtureBytes("token_hashes.json"), &v); err != nil {
+		panic("contract: invalid embedded token hash vectors: " + err.Error())
+	}
+	return v
+}
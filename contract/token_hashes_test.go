@@ -0,0 +1,26 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/ez-api/foundation/tokenhash"
+)
+
+func TestTokenHashVectors(t *testing.T) {
+	vectors := TokenHashes()
+	if len(vectors.Vectors) == 0 {
+		t.Fatal("expected token hash vectors")
+	}
+	var sawEmpty bool
+	for _, v := range vectors.Vectors {
+		if v.Token == "" {
+			sawEmpty = true
+		}
+		if got := tokenhash.HashToken(v.Token); got != v.Hash {
+			t.Errorf("HashToken(%q) = %s, want %s", v.Token, got, v.Hash)
+		}
+	}
+	if !sawEmpty {
+		t.Error("vectors should include the empty token")
+	}
+}
@@ -0,0 +1,118 @@
+package contract
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ez-api/foundation/jsoncodec"
+	"github.com/ez-api/foundation/modelcap"
+	"github.com/ez-api/foundation/provider"
+	"github.com/ez-api/foundation/routing"
+	"github.com/ez-api/foundation/scheduler"
+)
+
+// contractTypes maps contract names to the Go types that decode them.
+var contractTypes = map[string]reflect.Type{
+	ContractModel:            reflect.TypeOf(modelcap.Model{}),
+	ContractModelsMeta:       reflect.TypeOf(modelcap.Meta{}),
+	ContractBindingSnapshot:  reflect.TypeOf(routing.BindingSnapshot{}),
+	ContractProviderSnapshot: reflect.TypeOf(provider.Snapshot{}),
+	ContractSchedulerStatus:  reflect.TypeOf(scheduler.Status{}),
+}
+
+// UnknownFields decodes payload as the named contract and returns the dot-paths of keys
+// the current struct does not represent, e.g. "candidates.3.canary_percent", sorted.
+// A payload written by a newer CP decodes fine but loses these fields; DP can log or
+// count them at load time to notice version skew.
+//
+// Known keys come from the struct's json tags rather than from re-encoding the decoded
+// value, so fields that are present but zero (and dropped by omitempty) are not reported.
+func UnknownFields(schemaName string, payload []byte) ([]string, error) {
+	typ, ok := contractTypes[schemaName]
+	if !ok {
+		return nil, fmt.Errorf("unknown contract %q", schemaName)
+	}
+	decoded := reflect.New(typ).Interface()
+	if err := jsoncodec.Unmarshal(payload, decoded); err != nil {
+		return nil, &ValidationError{Contract: schemaName, Err: fmt.Errorf("decode: %w", err)}
+	}
+	var doc any
+	if err := jsoncodec.Unmarshal(payload, &doc); err != nil {
+		return nil, &ValidationError{Contract: schemaName, Err: fmt.Errorf("decode: %w", err)}
+	}
+
+	var unknown []string
+	collectUnknown(doc, typ, "", &unknown)
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+func collectUnknown(doc any, typ reflect.Type, prefix string, out *[]string) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch d := doc.(type) {
+	case map[string]any:
+		switch typ.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(typ)
+			for k, v := range d {
+				ft, ok := fields[k]
+				if !ok {
+					*out = append(*out, joinPath(prefix, k))
+					continue
+				}
+				collectUnknown(v, ft, joinPath(prefix, k), out)
+			}
+		case reflect.Map:
+			for k, v := range d {
+				collectUnknown(v, typ.Elem(), joinPath(prefix, k), out)
+			}
+		}
+	case []any:
+		if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+			return
+		}
+		for i, v := range d {
+			collectUnknown(v, typ.Elem(), joinPath(prefix, strconv.Itoa(i)), out)
+		}
+	}
+}
+
+// jsonFields returns the JSON keys of a struct type mapped to their field types,
+// following encoding/json naming rules for tags and embedded structs.
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for k, v := range jsonFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,45 @@
+package contract
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUnknownFields_GoldensHaveNone(t *testing.T) {
+	for name, golden := range currentGoldens() {
+		got, err := UnknownFields(name, golden)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(got) != 0 {
+			t.Errorf("%s golden has unknown fields %v", name, got)
+		}
+	}
+}
+
+func TestUnknownFields_Augmented(t *testing.T) {
+	payload := []byte(`{
+		"namespace": "ns",
+		"public_model": "m",
+		"rollout": {"stage": 2},
+		"candidates": [
+			{"group_id": 1, "route_group": "default", "weight": 0, "upstreams": {"1": "m"}},
+			{"group_id": 2, "route_group": "default", "upstreams": {"2": "m"}, "canary_percent": 5, "tags": ["a"]}
+		]
+	}`)
+	got, err := UnknownFields(ContractBindingSnapshot, payload)
+	if err != nil {
+		t.Fatalf("UnknownFields: %v", err)
+	}
+	want := "[candidates.1.canary_percent candidates.1.tags rollout]"
+	if fmt.Sprint(got) != want {
+		t.Fatalf("UnknownFields = %v, want %s", got, want)
+	}
+
+	if _, err := UnknownFields("nope", payload); err == nil {
+		t.Fatal("expected error for unknown contract")
+	}
+	if _, err := UnknownFields(ContractModel, []byte(`{"name":`)); err == nil {
+		t.Fatal("expected decode error")
+	}
+}
@@ -0,0 +1,78 @@
+package contract
+
+import (
+	"fmt"
+
+	"github.com/ez-api/foundation/jsoncodec"
+	"github.com/ez-api/foundation/modelcap"
+	"github.com/ez-api/foundation/provider"
+	"github.com/ez-api/foundation/routing"
+	"github.com/ez-api/foundation/scheduler"
+)
+
+// Contract names reported by ValidationError.
+const (
+	ContractModel            = "model"
+	ContractModelsMeta       = "models_meta"
+	ContractBindingSnapshot  = "binding_snapshot"
+	ContractProviderSnapshot = "provider_snapshot"
+	ContractSchedulerStatus  = "scheduler_status"
+	ContractRequestID        = "request_id"
+)
+
+// payloadValidators maps each contract name to its exported payload validator.
+var payloadValidators = map[string]func([]byte) error{
+	ContractModel:            ValidateModelPayload,
+	ContractModelsMeta:       ValidateMetaPayload,
+	ContractBindingSnapshot:  ValidateBindingSnapshotPayload,
+	ContractProviderSnapshot: ValidateProviderSnapshotPayload,
+	ContractSchedulerStatus:  ValidateSchedulerStatusPayload,
+}
+
+// ValidationError identifies which contract a payload failed.
+type ValidationError struct {
+	Contract string
+	Err      error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("contract %s: %v", e.Contract, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// ValidateModelPayload decodes a meta:models value and validates it as modelcap.Model.
+func ValidateModelPayload(payload []byte) error {
+	return validatePayload[modelcap.Model](ContractModel, payload)
+}
+
+// ValidateMetaPayload decodes a meta:models_meta payload and validates it as modelcap.Meta.
+func ValidateMetaPayload(payload []byte) error {
+	return validatePayload[modelcap.Meta](ContractModelsMeta, payload)
+}
+
+// ValidateBindingSnapshotPayload decodes and validates a routing.BindingSnapshot payload.
+func ValidateBindingSnapshotPayload(payload []byte) error {
+	return validatePayload[routing.BindingSnapshot](ContractBindingSnapshot, payload)
+}
+
+// ValidateProviderSnapshotPayload decodes and validates a provider.Snapshot payload.
+func ValidateProviderSnapshotPayload(payload []byte) error {
+	return validatePayload[provider.Snapshot](ContractProviderSnapshot, payload)
+}
+
+// ValidateSchedulerStatusPayload decodes and validates a scheduler.Status payload.
+func ValidateSchedulerStatusPayload(payload []byte) error {
+	return validatePayload[scheduler.Status](ContractSchedulerStatus, payload)
+}
+
+func validatePayload[T interface{ Validate() error }](name string, payload []byte) error {
+	var v T
+	if err := jsoncodec.Unmarshal(payload, &v); err != nil {
+		return &ValidationError{Contract: name, Err: fmt.Errorf("decode: %w", err)}
+	}
+	if err := v.Validate(); err != nil {
+		return &ValidationError{Contract: name, Err: err}
+	}
+	return nil
+}
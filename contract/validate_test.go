@@ -0,0 +1,68 @@
+package contract
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidatePayloads_Goldens(t *testing.T) {
+	cases := []struct {
+		name     string
+		validate func([]byte) error
+		golden   []byte
+	}{
+		{ContractModel, ValidateModelPayload, ModelSnapshotJSON()},
+		{ContractModelsMeta, ValidateMetaPayload, ModelsMetaJSON()},
+		{ContractBindingSnapshot, ValidateBindingSnapshotPayload, BindingSnapshotJSON()},
+		{ContractProviderSnapshot, ValidateProviderSnapshotPayload, ProviderSnapshotJSON()},
+		{ContractSchedulerStatus, ValidateSchedulerStatusPayload, SchedulerStatusJSON()},
+	}
+	for _, tc := range cases {
+		if err := tc.validate(tc.golden); err != nil {
+			t.Errorf("%s golden: %v", tc.name, err)
+		}
+	}
+}
+
+func TestValidatePayloads_Corrupted(t *testing.T) {
+	cases := []struct {
+		contract string
+		validate func([]byte) error
+		payload  []byte
+	}{
+		{ContractModel, ValidateModelPayload, []byte(`{"name":"  "}`)},
+		{ContractModel, ValidateModelPayload, []byte(`{"name":"m","context_window":-1}`)},
+		{ContractModel, ValidateModelPayload, []byte(`{"name":`)},
+		{ContractModelsMeta, ValidateMetaPayload, corrupt(ModelsMetaJSON(), `"source": "models.dev"`, `"source": ""`)},
+		{ContractModelsMeta, ValidateMetaPayload, corrupt(ModelsMetaJSON(), `"checksum": "0000`, `"checksum": "zz00`)},
+		{ContractBindingSnapshot, ValidateBindingSnapshotPayload, corrupt(BindingSnapshotJSON(), `"namespace": "ns"`, `"namespace": ""`)},
+		{ContractBindingSnapshot, ValidateBindingSnapshotPayload, corrupt(BindingSnapshotJSON(), `"selector_type": "exact"`, `"selector_type": "fuzzy"`)},
+		{ContractBindingSnapshot, ValidateBindingSnapshotPayload, corrupt(BindingSnapshotJSON(), `"error": "config_error"`, `"error": "boom"`)},
+		{ContractBindingSnapshot, ValidateBindingSnapshotPayload, corrupt(BindingSnapshotJSON(), `"weight": 80`, `"weight": -1`)},
+		{ContractBindingSnapshot, ValidateBindingSnapshotPayload, corrupt(BindingSnapshotJSON(), `"max_retries": 2`, `"max_retries": 99`)},
+		{ContractProviderSnapshot, ValidateProviderSnapshotPayload, corrupt(ProviderSnapshotJSON(), `"id": 42`, `"id": 0`)},
+		{ContractProviderSnapshot, ValidateProviderSnapshotPayload, corrupt(ProviderSnapshotJSON(), `"type": "vertex-express"`, `"type": " "`)},
+		{ContractSchedulerStatus, ValidateSchedulerStatusPayload, corrupt(SchedulerStatusJSON(), `"schedule": "@every 30s",`, ``)},
+		{ContractSchedulerStatus, ValidateSchedulerStatusPayload, corrupt(SchedulerStatusJSON(), `"next_run": "2025-01-01T01:00:00Z",`, ``)},
+	}
+	for i, tc := range cases {
+		err := tc.validate(tc.payload)
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("case %d: expected *ValidationError, got %v", i, err)
+			continue
+		}
+		if verr.Contract != tc.contract {
+			t.Errorf("case %d: contract = %q, want %q", i, verr.Contract, tc.contract)
+		}
+	}
+}
+
+func corrupt(golden []byte, old, new string) []byte {
+	s := string(golden)
+	if !strings.Contains(s, old) {
+		panic("golden does not contain " + old)
+	}
+	return []byte(strings.Replace(s, old, new, 1))
+}
@@ -1,13 +1,86 @@
 package group
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 const Default = "default"
 
+// MaxLen is the maximum length of a group name accepted by Validate.
+const MaxLen = 64
+
+var ErrEmpty = errors.New("group name required")
+
+// Normalize trims and lowercases value, mapping empty (or whitespace-only) input to Default.
+// Repeated path separators are collapsed ("org//team" -> "org/team").
+// Before v0.4 it returned non-empty input untouched; stored group keys that differ only
+// by case or surrounding whitespace now collapse into one group.
 func Normalize(value string) string {
-	if strings.TrimSpace(value) == "" {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
 		return Default
 	}
+	for strings.Contains(value, "//") {
+		value = strings.ReplaceAll(value, "//", "/")
+	}
 	return value
 }
 
+// Validate reports whether value is an acceptable stored group name:
+// at most 64 characters in total, made of 1–4 '/'-separated segments, each
+// non-empty, of [a-z0-9_-], and not starting with '-' or '_'.
+// Default is flat and cannot be used as the root of a hierarchy.
+// Group names end up in Redis key fragments, so CP write paths must call this.
+func Validate(value string) error {
+	if value == "" {
+		return ErrEmpty
+	}
+	if len(value) > MaxLen {
+		return fmt.Errorf("group name too long: %d > %d", len(value), MaxLen)
+	}
+	if strings.HasPrefix(value, PathSeparator) || strings.HasSuffix(value, PathSeparator) {
+		return fmt.Errorf("invalid group name %q: must not start or end with %q", value, PathSeparator)
+	}
+	segments := strings.Split(value, PathSeparator)
+	if len(segments) > MaxDepth {
+		return fmt.Errorf("invalid group name %q: more than %d segments", value, MaxDepth)
+	}
+	if len(segments) > 1 && segments[0] == Default {
+		return fmt.Errorf("invalid group name %q: %q cannot have children", value, Default)
+	}
+	for _, seg := range segments {
+		if err := validateSegment(value, seg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSegment(value, seg string) error {
+	if seg == "" {
+		return fmt.Errorf("invalid group name %q: empty segment", value)
+	}
+	if seg[0] == '-' || seg[0] == '_' {
+		return fmt.Errorf("invalid group name %q: must not start with a separator", value)
+	}
+	for i := 0; i < len(seg); i++ {
+		c := seg[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return fmt.Errorf("invalid group name %q: character %q not allowed", value, c)
+		}
+	}
+	return nil
+}
+
+// MustNormalize normalizes value like Normalize and validates the result.
+func MustNormalize(value string) (string, error) {
+	value = Normalize(value)
+	if err := Validate(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
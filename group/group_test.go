@@ -0,0 +1,150 @@
+package group
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		in string
+		ok bool
+	}{
+		{"default", true},
+		{"premium-1", true},
+		{"team_a", true},
+		{"0", true},
+		{strings.Repeat("a", MaxLen), true},
+		{"", false},
+		{strings.Repeat("a", MaxLen+1), false},
+		{"-lead", false},
+		{"_lead", false},
+		{"My Group!!", false},
+		{"Default", false},
+		{"a b", false},
+		{"a.b", false},
+	}
+	for _, tc := range cases {
+		err := Validate(tc.in)
+		if (err == nil) != tc.ok {
+			t.Errorf("Validate(%q) err=%v, want ok=%v", tc.in, err, tc.ok)
+		}
+	}
+}
+
+func TestMustNormalize(t *testing.T) {
+	cases := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"", Default, true},
+		{"  Premium ", "premium", true},
+		{"My Group!!", "", false},
+	}
+	for _, tc := range cases {
+		got, err := MustNormalize(tc.in)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("MustNormalize(%q) = %q, %v; want %q ok=%v", tc.in, got, err, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"":           Default,
+		"   ":        Default,
+		"\t\n":       Default,
+		" Default ":  Default,
+		"DEFAULT":    Default,
+		"default":    Default,
+		"Premium":    "premium",
+		"  beta-1  ": "beta-1",
+	}
+	for in, want := range cases {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseList(t *testing.T) {
+	got, err := ParseList("default, Premium ,beta,,premium  gamma")
+	if err != nil {
+		t.Fatalf("ParseList: %v", err)
+	}
+	want := []string{"default", "premium", "beta", "gamma"}
+	if JoinList(got) != JoinList(want) {
+		t.Fatalf("ParseList = %v, want %v", got, want)
+	}
+
+	if got, _ := ParseList("  , ,"); len(got) != 0 {
+		t.Fatalf("expected empty list, got %v", got)
+	}
+}
+
+func TestParseListTooMany(t *testing.T) {
+	names := make([]string, MaxListLen+1)
+	for i := range names {
+		names[i] = "g" + strings.Repeat("x", i)
+	}
+	if _, err := ParseList(JoinList(names[:MaxListLen])); err != nil {
+		t.Fatalf("expected %d groups to be accepted: %v", MaxListLen, err)
+	}
+	if _, err := ParseList(JoinList(names)); err == nil {
+		t.Fatal("expected error for too many groups")
+	}
+}
+
+func TestParseListRoundTrip(t *testing.T) {
+	inputs := [][]string{
+		{},
+		{Default},
+		{"a", "b", "c"},
+		{"team-alpha", "team_beta", "0"},
+	}
+	for _, x := range inputs {
+		got, err := ParseList(JoinList(x))
+		if err != nil {
+			t.Fatalf("ParseList(%q): %v", JoinList(x), err)
+		}
+		if len(got) != len(x) || JoinList(got) != JoinList(x) {
+			t.Errorf("round trip of %v = %v", x, got)
+		}
+	}
+}
+
+func TestReservedNames(t *testing.T) {
+	want := []string{"default", "internal", "canary", "all"}
+	got := ReservedNames()
+	if JoinList(got) != JoinList(want) {
+		t.Fatalf("reserved names changed: got %v, want %v", got, want)
+	}
+	for _, n := range want {
+		if !IsReserved(n) || !IsReserved(" "+strings.ToUpper(n)+" ") {
+			t.Errorf("expected %q to be reserved", n)
+		}
+	}
+	if IsReserved("premium") {
+		t.Error("premium should not be reserved")
+	}
+}
+
+func TestValidateUserGroup(t *testing.T) {
+	if err := ValidateUserGroup(Default); err != nil {
+		t.Fatalf("default must be assignable: %v", err)
+	}
+	if err := ValidateUserGroup("premium"); err != nil {
+		t.Fatalf("premium: %v", err)
+	}
+	for _, n := range []string{Internal, Canary, All} {
+		var rerr *ReservedError
+		if err := ValidateUserGroup(n); !errors.As(err, &rerr) || rerr.Name != n {
+			t.Errorf("ValidateUserGroup(%q) = %v, want *ReservedError", n, err)
+		}
+	}
+	var rerr *ReservedError
+	if err := ValidateUserGroup("Bad Name"); err == nil || errors.As(err, &rerr) {
+		t.Errorf("expected charset error, got %v", err)
+	}
+}
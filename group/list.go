@@ -0,0 +1,40 @@
+package group
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxListLen bounds how many groups ParseList accepts from a single string.
+const MaxListLen = 32
+
+// ParseList splits raw on commas and whitespace, normalizes each element, drops empty
+// elements, and deduplicates while preserving first-seen order.
+// An input with more than MaxListLen distinct groups is rejected.
+func ParseList(raw string) ([]string, error) {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	out := make([]string, 0, len(fields))
+	seen := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		if strings.TrimSpace(f) == "" {
+			continue
+		}
+		name := Normalize(f)
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		if len(out) == MaxListLen {
+			return nil, fmt.Errorf("too many groups: more than %d", MaxListLen)
+		}
+		seen[name] = struct{}{}
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+// JoinList returns the canonical comma-separated form of names (no spaces).
+func JoinList(names []string) string {
+	return strings.Join(names, ",")
+}
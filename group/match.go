@@ -0,0 +1,59 @@
+package group
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Wildcard is the only pattern metacharacter; it is allowed solely as the last character.
+const Wildcard = "*"
+
+// ValidatePattern checks a group selector pattern: either a valid group name,
+// a valid name prefix (or path followed by '/') and a single trailing '*', or a lone "*".
+func ValidatePattern(pattern string) error {
+	prefix, wild := strings.CutSuffix(pattern, Wildcard)
+	if strings.Contains(prefix, Wildcard) {
+		return fmt.Errorf("invalid group pattern %q: '*' is only allowed at the end", pattern)
+	}
+	if wild && prefix == "" {
+		return nil
+	}
+	if wild {
+		// "org/*" selects every child of org.
+		prefix = strings.TrimSuffix(prefix, PathSeparator)
+	}
+	return Validate(prefix)
+}
+
+// Match reports whether name matches pattern. Both are normalized first.
+// A trailing '*' matches any (possibly empty) suffix; a lone "*" matches everything,
+// including Default. Patterns without '*' must match exactly.
+func Match(pattern, name string) bool {
+	pattern, name = Normalize(pattern), Normalize(name)
+	if prefix, ok := strings.CutSuffix(pattern, Wildcard); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return pattern == name
+}
+
+// MatchAny reports whether name matches at least one of patterns.
+func MatchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if Match(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterMatching returns the normalized names that match at least one of patterns,
+// preserving input order.
+func FilterMatching(patterns []string, names []string) []string {
+	var out []string
+	for _, n := range names {
+		if MatchAny(patterns, n) {
+			out = append(out, Normalize(n))
+		}
+	}
+	return out
+}
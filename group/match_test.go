@@ -0,0 +1,51 @@
+package group
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*", "anything", true},
+		{"*", "", true},
+		{"*", Default, true},
+		{"team-*", "team-alpha", true},
+		{"team-*", "Team-Beta", true},
+		{"team-*", "team-", true},
+		{"team-*", "team", false},
+		{"team-*", "teams-alpha", false},
+		{"team-", "team-", true},
+		{"team-", "team-alpha", false},
+		{"premium", "PREMIUM", true},
+		{"premium", "premium2", false},
+	}
+	for _, tc := range cases {
+		if got := Match(tc.pattern, tc.name); got != tc.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tc.pattern, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	for _, p := range []string{"*", "team-*", "team", "a*"} {
+		if err := ValidatePattern(p); err != nil {
+			t.Errorf("ValidatePattern(%q): %v", p, err)
+		}
+	}
+	for _, p := range []string{"", "*team", "te*am", "team-**", "Team*", "**"} {
+		if err := ValidatePattern(p); err == nil {
+			t.Errorf("ValidatePattern(%q): expected error", p)
+		}
+	}
+}
+
+func TestFilterMatching(t *testing.T) {
+	got := FilterMatching([]string{"team-*", "beta"}, []string{"team-a", "alpha", "Beta", "team-b"})
+	if JoinList(got) != "team-a,beta,team-b" {
+		t.Fatalf("FilterMatching = %v", got)
+	}
+	if MatchAny(nil, "x") {
+		t.Fatal("no patterns must match nothing")
+	}
+}
@@ -0,0 +1,36 @@
+package group
+
+import "strings"
+
+// PathSeparator separates segments of a hierarchical group name such as "org/team/project".
+const PathSeparator = "/"
+
+// MaxDepth is the maximum number of segments accepted by Validate.
+const MaxDepth = 4
+
+// Parts returns the segments of the normalized name.
+// A single-segment group yields a one-element slice.
+func Parts(name string) []string {
+	return strings.Split(Normalize(name), PathSeparator)
+}
+
+// Parent returns the parent of the normalized name, or false for a top-level group.
+func Parent(name string) (string, bool) {
+	name = Normalize(name)
+	i := strings.LastIndex(name, PathSeparator)
+	if i <= 0 {
+		return "", false
+	}
+	return name[:i], true
+}
+
+// IsAncestor reports whether ancestor is a strict ancestor of name
+// ("org" is an ancestor of "org/team" and "org/team/project", but not of "org" or "organic").
+// Default is never an ancestor.
+func IsAncestor(ancestor, name string) bool {
+	ancestor, name = Normalize(ancestor), Normalize(name)
+	if ancestor == Default {
+		return false
+	}
+	return strings.HasPrefix(name, ancestor+PathSeparator)
+}
@@ -0,0 +1,108 @@
+package group
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNormalizePath(t *testing.T) {
+	cases := map[string]string{
+		"Org//Team":    "org/team",
+		"org///team//": "org/team/",
+		"org":          "org",
+	}
+	for in, want := range cases {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestValidatePath(t *testing.T) {
+	valid := []string{"org", "org/team", "org/team/project", "a/b/c/d", "org-1/team_2"}
+	for _, v := range valid {
+		if err := Validate(v); err != nil {
+			t.Errorf("Validate(%q): %v", v, err)
+		}
+	}
+	invalid := []string{"/org", "org/", "a/b/c/d/e", "org//team", "org/-team", "org/Team", "default/team", "org/ team"}
+	for _, v := range invalid {
+		if err := Validate(v); err == nil {
+			t.Errorf("Validate(%q): expected error", v)
+		}
+	}
+	if _, err := MustNormalize("Org//Team/"); err == nil {
+		t.Error("expected trailing slash to be rejected after normalization")
+	}
+}
+
+func TestPartsAndParent(t *testing.T) {
+	if got := fmt.Sprint(Parts("Org/Team/Project")); got != "[org team project]" {
+		t.Fatalf("Parts = %s", got)
+	}
+	if got := fmt.Sprint(Parts("")); got != "[default]" {
+		t.Fatalf("Parts(empty) = %s", got)
+	}
+	chain := []string{}
+	for p, ok := Parent("org/team/project"); ok; p, ok = Parent(p) {
+		chain = append(chain, p)
+	}
+	if fmt.Sprint(chain) != "[org/team org]" {
+		t.Fatalf("parent chain = %v", chain)
+	}
+	if _, ok := Parent(Default); ok {
+		t.Fatal("default has no parent")
+	}
+}
+
+func TestIsAncestor(t *testing.T) {
+	cases := []struct {
+		ancestor, name string
+		want           bool
+	}{
+		{"org", "org/team", true},
+		{"org", "org/team/project", true},
+		{"org/team", "org/team/project", true},
+		{"org", "org", false},
+		{"org", "organic", false},
+		{"org/team", "org", false},
+		{Default, "default/x", false},
+		{"", "x", false},
+	}
+	for _, tc := range cases {
+		if got := IsAncestor(tc.ancestor, tc.name); got != tc.want {
+			t.Errorf("IsAncestor(%q, %q) = %v, want %v", tc.ancestor, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSetIntersectsAncestors(t *testing.T) {
+	grants := NewSet("org", Default)
+	if grants.Intersects(NewSet("org/team")) {
+		t.Fatal("plain Intersects must not follow hierarchy")
+	}
+	if !grants.Intersects(NewSet("org/team/project"), WithAncestors()) {
+		t.Fatal("expected ancestor grant to cover child")
+	}
+	if NewSet("org/team").Intersects(NewSet("org"), WithAncestors()) {
+		t.Fatal("child grant must not cover parent")
+	}
+	if grants.Intersects(NewSet("other"), WithAncestors()) {
+		t.Fatal("unrelated groups must not intersect")
+	}
+	if !grants.Intersects(NewSet(Default), WithAncestors()) {
+		t.Fatal("exact match must still intersect")
+	}
+	if (Set{}).Intersects(NewSet("org"), WithAncestors()) {
+		t.Fatal("empty set never intersects")
+	}
+}
+
+func TestMatchPathPattern(t *testing.T) {
+	if err := ValidatePattern("org/*"); err != nil {
+		t.Fatalf("ValidatePattern(org/*): %v", err)
+	}
+	if !Match("org/*", "org/team") || Match("org/*", "org") {
+		t.Fatal("unexpected org/* match result")
+	}
+}
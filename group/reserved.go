@@ -0,0 +1,52 @@
+package group
+
+import "fmt"
+
+const (
+	Internal = "internal"
+	Canary   = "canary"
+	All      = "all"
+)
+
+// reserved are system group names that end users must not claim.
+// Default is reserved too, but it remains assignable: it is what every
+// unassigned key already belongs to, so choosing it explicitly grants nothing.
+var reserved = map[string]struct{}{
+	Default:  {},
+	Internal: {},
+	Canary:   {},
+	All:      {},
+}
+
+// ReservedNames returns the reserved group names in a stable order.
+func ReservedNames() []string {
+	return []string{Default, Internal, Canary, All}
+}
+
+// IsReserved reports whether name (normalized) is a reserved system group.
+func IsReserved(name string) bool {
+	_, ok := reserved[Normalize(name)]
+	return ok
+}
+
+// ReservedError is returned by ValidateUserGroup when a user tries to claim a reserved group.
+type ReservedError struct {
+	Name string
+}
+
+func (e *ReservedError) Error() string {
+	return fmt.Sprintf("group name %q is reserved", e.Name)
+}
+
+// ValidateUserGroup validates a group name supplied by an end user: it must pass
+// Validate and must not be reserved. Default is the one reserved name users may assign.
+// Reserved names are reported as *ReservedError so the API layer can distinguish them.
+func ValidateUserGroup(name string) error {
+	if err := Validate(name); err != nil {
+		return err
+	}
+	if name != Default && IsReserved(name) {
+		return &ReservedError{Name: name}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package group
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Set is an unordered collection of normalized group names.
+// The zero value is an empty set; it contains nothing, not even Default.
+type Set struct {
+	m map[string]struct{}
+}
+
+// NewSet builds a Set from names, normalizing and deduplicating them.
+// Empty names are skipped rather than mapped to Default.
+func NewSet(names ...string) Set {
+	s := Set{m: make(map[string]struct{}, len(names))}
+	for _, n := range names {
+		if strings.TrimSpace(n) == "" {
+			continue
+		}
+		s.m[Normalize(n)] = struct{}{}
+	}
+	return s
+}
+
+// Len returns the number of groups in the set.
+func (s Set) Len() int { return len(s.m) }
+
+// Has reports whether name (normalized) is in the set.
+func (s Set) Has(name string) bool {
+	if len(s.m) == 0 || strings.TrimSpace(name) == "" {
+		return false
+	}
+	_, ok := s.m[Normalize(name)]
+	return ok
+}
+
+// IntersectOption adjusts how Intersects compares groups.
+type IntersectOption func(*intersectConfig)
+
+type intersectConfig struct {
+	ancestors bool
+}
+
+// WithAncestors makes Intersects also match when a group in the receiver is an
+// ancestor of a group in other, so a grant on "org" covers "org/team".
+func WithAncestors() IntersectOption {
+	return func(c *intersectConfig) { c.ancestors = true }
+}
+
+// Intersects reports whether s and other share at least one group.
+// An empty set never intersects anything.
+func (s Set) Intersects(other Set, opts ...IntersectOption) bool {
+	var cfg intersectConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.ancestors {
+		return s.intersectsAncestors(other)
+	}
+	small, large := s.m, other.m
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for n := range small {
+		if _, ok := large[n]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Set) intersectsAncestors(other Set) bool {
+	for n := range other.m {
+		if _, ok := s.m[n]; ok {
+			return true
+		}
+		for p, ok := Parent(n); ok; p, ok = Parent(p) {
+			if _, hit := s.m[p]; hit {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsSubsetOf reports whether every group in s is also in other.
+func (s Set) IsSubsetOf(other Set) bool {
+	if len(s.m) > len(other.m) {
+		return false
+	}
+	for n := range s.m {
+		if _, ok := other.m[n]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set containing the groups of both s and other.
+func (s Set) Union(other Set) Set {
+	out := Set{m: make(map[string]struct{}, len(s.m)+len(other.m))}
+	for n := range s.m {
+		out.m[n] = struct{}{}
+	}
+	for n := range other.m {
+		out.m[n] = struct{}{}
+	}
+	return out
+}
+
+// Names returns the groups in the set, sorted.
+func (s Set) Names() []string {
+	out := make([]string, 0, len(s.m))
+	for n := range s.m {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// MarshalJSON encodes the set as a sorted JSON array.
+func (s Set) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Names())
+}
+
+// UnmarshalJSON decodes a JSON array of names, normalizing and deduplicating them.
+func (s *Set) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*s = NewSet(names...)
+	return nil
+}
@@ -0,0 +1,97 @@
+package group
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestSetBasics(t *testing.T) {
+	s := NewSet(" Premium", "beta", "premium", "")
+	if s.Len() != 2 {
+		t.Fatalf("expected 2 groups, got %v", s.Names())
+	}
+	if !s.Has("PREMIUM") || !s.Has("beta") {
+		t.Fatalf("expected membership, got %v", s.Names())
+	}
+	if s.Has(Default) || s.Has("") {
+		t.Fatal("set must not implicitly contain default")
+	}
+	if !NewSet("beta").IsSubsetOf(s) || s.IsSubsetOf(NewSet("beta")) {
+		t.Fatal("unexpected subset result")
+	}
+	if got := s.Union(NewSet("alpha")).Names(); fmt.Sprint(got) != "[alpha beta premium]" {
+		t.Fatalf("union = %v", got)
+	}
+}
+
+func TestSetEmpty(t *testing.T) {
+	var zero Set
+	empty := NewSet()
+	if zero.Has(Default) || empty.Has(Default) {
+		t.Fatal("empty set must contain nothing")
+	}
+	if zero.Intersects(NewSet(Default)) || NewSet(Default).Intersects(empty) || zero.Intersects(zero) {
+		t.Fatal("empty set must never intersect")
+	}
+	if !zero.IsSubsetOf(empty) {
+		t.Fatal("empty set is a subset of every set")
+	}
+	b, err := json.Marshal(zero)
+	if err != nil || string(b) != "[]" {
+		t.Fatalf("marshal empty = %s, %v", b, err)
+	}
+}
+
+func TestSetJSON(t *testing.T) {
+	b, err := json.Marshal(NewSet("b", "a"))
+	if err != nil || string(b) != `["a","b"]` {
+		t.Fatalf("marshal = %s, %v", b, err)
+	}
+	var s Set
+	if err := json.Unmarshal([]byte(`["B"," a ","b"]`), &s); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if fmt.Sprint(s.Names()) != "[a b]" {
+		t.Fatalf("unmarshal = %v", s.Names())
+	}
+}
+
+func benchNames(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("group-%d", i)
+	}
+	return out
+}
+
+func sliceIntersects(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func BenchmarkIntersects(b *testing.B) {
+	for _, n := range []int{5, 20, 50} {
+		names := benchNames(2 * n)
+		left, right := names[:n], append(names[n:], names[n-1])
+
+		b.Run(fmt.Sprintf("set/%d", n), func(b *testing.B) {
+			l, r := NewSet(left...), NewSet(right...)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = l.Intersects(r)
+			}
+		})
+		b.Run(fmt.Sprintf("slice/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = sliceIntersects(left, right)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+)
+
+// ErrShardCount is returned by Shard for a non-positive shard count.
+var ErrShardCount = errors.New("shard count must be > 0")
+
+// Shard maps a group name to a shard index in [0, n): FNV-1a 64 of the normalized name's
+// bytes, modulo n. The result is part of the cross-service contract (see the contract
+// package's group shard vectors), so the hash and normalization must never change.
+func Shard(name string, n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: %d", ErrShardCount, n)
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(Normalize(name)))
+	return int(h.Sum64() % uint64(n)), nil
+}
+
+// ShardKey returns the canonical key of name's shard: prefix + ":" + Shard(name, n)
+// (e.g. "quota:3"). A non-positive n is treated as a single shard.
+func ShardKey(prefix, name string, n int) string {
+	shard, err := Shard(name, n)
+	if err != nil {
+		shard = 0
+	}
+	return prefix + ":" + strconv.Itoa(shard)
+}
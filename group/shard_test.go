@@ -0,0 +1,56 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestShard(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if _, err := Shard("team-a", n); !errors.Is(err, ErrShardCount) {
+			t.Errorf("Shard(n=%d) err = %v, want ErrShardCount", n, err)
+		}
+	}
+	want, err := Shard("org/team", 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, raw := range []string{" ORG/Team ", "org//team"} {
+		if got, _ := Shard(raw, 16); got != want {
+			t.Errorf("Shard(%q) = %d, want %d (same as normalized name)", raw, got, want)
+		}
+	}
+	if a, _ := Shard("", 16); a != mustShard(t, Default, 16) {
+		t.Errorf("empty name should shard like %q", Default)
+	}
+	if got := ShardKey("quota", "org/team", 16); got != fmt.Sprintf("quota:%d", want) {
+		t.Errorf("ShardKey = %q", got)
+	}
+	if got := ShardKey("quota", "org/team", 0); got != "quota:0" {
+		t.Errorf("ShardKey with n=0 = %q, want quota:0", got)
+	}
+}
+
+func TestShardUniformity(t *testing.T) {
+	const n, names = 16, 32000
+	counts := make([]int, n)
+	for i := 0; i < names; i++ {
+		counts[mustShard(t, fmt.Sprintf("tenant-%d/team-%d", i/8, i%8), n)]++
+	}
+	expected := names / n
+	for shard, c := range counts {
+		if c < expected*9/10 || c > expected*11/10 {
+			t.Errorf("shard %d has %d names, want within 10%% of %d", shard, c, expected)
+		}
+	}
+}
+
+func mustShard(t *testing.T, name string, n int) int {
+	t.Helper()
+	s, err := Shard(name, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
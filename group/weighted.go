@@ -0,0 +1,105 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// WeightedGroup is a route group with a relative traffic weight.
+type WeightedGroup struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+// WeightedGroups is an ordered traffic split across route groups, e.g. 90% default / 10% canary.
+// It replaces parallel name/weight arrays in configs; consumers of
+// routing.BindingCandidate.RouteGroup pick a group from it per request.
+type WeightedGroups []WeightedGroup
+
+// Validate requires normalized-unique, valid names, non-negative weights, and at least one positive weight.
+func (w WeightedGroups) Validate() error {
+	if len(w) == 0 {
+		return errors.New("weighted groups required")
+	}
+	seen := make(map[string]struct{}, len(w))
+	total := 0
+	for _, g := range w {
+		name := Normalize(g.Name)
+		if err := Validate(name); err != nil {
+			return err
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("duplicate group %q", name)
+		}
+		seen[name] = struct{}{}
+		if g.Weight < 0 {
+			return fmt.Errorf("group %q: weight must be >= 0", name)
+		}
+		total += g.Weight
+	}
+	if total <= 0 {
+		return errors.New("at least one group must have a positive weight")
+	}
+	return nil
+}
+
+// Pick returns a normalized group name chosen with probability proportional to its weight.
+// It returns "" if no group has a positive weight.
+func (w WeightedGroups) Pick(r *rand.Rand) string {
+	total := w.total()
+	if total <= 0 {
+		return ""
+	}
+	n := r.Intn(total)
+	for _, g := range w {
+		if g.Weight <= 0 {
+			continue
+		}
+		if n < g.Weight {
+			return Normalize(g.Name)
+		}
+		n -= g.Weight
+	}
+	return ""
+}
+
+// Normalize returns a copy with normalized names and weights scaled to sum to exactly 100,
+// for display. Rounding remainders go to the groups with the largest fractional parts.
+func (w WeightedGroups) Normalize() WeightedGroups {
+	out := make(WeightedGroups, len(w))
+	total := w.total()
+	type rem struct {
+		idx  int
+		frac int
+	}
+	rems := make([]rem, 0, len(w))
+	sum := 0
+	for i, g := range w {
+		out[i].Name = Normalize(g.Name)
+		if total <= 0 || g.Weight <= 0 {
+			continue
+		}
+		scaled := g.Weight * 100
+		out[i].Weight = scaled / total
+		sum += out[i].Weight
+		rems = append(rems, rem{idx: i, frac: scaled % total})
+	}
+	sort.SliceStable(rems, func(a, b int) bool { return rems[a].frac > rems[b].frac })
+	for i := 0; sum < 100 && i < len(rems); i++ {
+		out[rems[i].idx].Weight++
+		sum++
+	}
+	return out
+}
+
+func (w WeightedGroups) total() int {
+	total := 0
+	for _, g := range w {
+		if g.Weight > 0 {
+			total += g.Weight
+		}
+	}
+	return total
+}
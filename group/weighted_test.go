@@ -0,0 +1,74 @@
+package group
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWeightedGroupsValidate(t *testing.T) {
+	ok := WeightedGroups{{Name: Default, Weight: 90}, {Name: "canary", Weight: 10}, {Name: "off", Weight: 0}}
+	if err := ok.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	bad := []WeightedGroups{
+		nil,
+		{{Name: "a", Weight: 0}},
+		{{Name: "a", Weight: -1}, {Name: "b", Weight: 5}},
+		{{Name: "Canary", Weight: 1}, {Name: " canary ", Weight: 1}},
+		{{Name: "", Weight: 1}, {Name: "DEFAULT", Weight: 1}},
+		{{Name: "bad name", Weight: 1}},
+	}
+	for _, w := range bad {
+		if err := w.Validate(); err == nil {
+			t.Errorf("expected error for %+v", w)
+		}
+	}
+}
+
+func TestWeightedGroupsPickDistribution(t *testing.T) {
+	w := WeightedGroups{{Name: Default, Weight: 90}, {Name: "Canary", Weight: 10}, {Name: "off", Weight: 0}}
+	r := rand.New(rand.NewSource(1))
+	const n = 100000
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		counts[w.Pick(r)]++
+	}
+	if counts["off"] != 0 {
+		t.Fatalf("zero-weight group picked %d times", counts["off"])
+	}
+	if got := float64(counts["canary"]) / n; math.Abs(got-0.10) > 0.01 {
+		t.Fatalf("canary share = %.3f, want ~0.10", got)
+	}
+	if got := (WeightedGroups{{Name: "a"}}).Pick(r); got != "" {
+		t.Fatalf("expected empty pick, got %q", got)
+	}
+}
+
+func TestWeightedGroupsNormalize(t *testing.T) {
+	got := WeightedGroups{{Name: "A", Weight: 1}, {Name: "b", Weight: 1}, {Name: "c", Weight: 1}}.Normalize()
+	sum := 0
+	for _, g := range got {
+		sum += g.Weight
+	}
+	if sum != 100 || got[0].Name != "a" {
+		t.Fatalf("Normalize = %+v (sum %d)", got, sum)
+	}
+}
+
+func TestWeightedGroupsJSON(t *testing.T) {
+	w := WeightedGroups{{Name: Default, Weight: 90}, {Name: "canary", Weight: 10}}
+	b, err := json.Marshal(w)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	const want = `[{"name":"default","weight":90},{"name":"canary","weight":10}]`
+	if string(b) != want {
+		t.Fatalf("marshal = %s, want %s", b, want)
+	}
+	var back WeightedGroups
+	if err := json.Unmarshal(b, &back); err != nil || len(back) != 2 || back[1] != w[1] {
+		t.Fatalf("unmarshal = %+v, %v", back, err)
+	}
+}
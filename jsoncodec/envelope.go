@@ -0,0 +1,176 @@
+package jsoncodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Field is one top-level member of a JSON object, kept as raw bytes.
+type Field struct {
+	// RawKey is the key exactly as written, including quotes and escapes.
+	RawKey []byte
+	Key    string
+	Value  json.RawMessage
+}
+
+// Envelope is a JSON object split into its top-level members in document order,
+// for two-phase parsing: decode the few keys you need and pass the rest through
+// untouched. Duplicate keys are kept in place; lookups see the last one, as
+// encoding/json does.
+type Envelope []Field
+
+// DecodeEnvelope splits a JSON object into its top-level members without decoding values.
+func DecodeEnvelope(data []byte) (Envelope, error) {
+	pos := skipSpace(data, 0)
+	if pos >= len(data) || data[pos] != '{' {
+		return nil, errors.New("jsoncodec: envelope must be a JSON object")
+	}
+	pos = skipSpace(data, pos+1)
+	env := Envelope{}
+	if pos < len(data) && data[pos] == '}' {
+		return env, trailing(data, pos+1)
+	}
+	for {
+		end, err := scanString(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		rawKey := data[pos:end]
+		var key string
+		if err := json.Unmarshal(rawKey, &key); err != nil {
+			return nil, fmt.Errorf("jsoncodec: invalid key at offset %d: %w", pos, err)
+		}
+		pos = skipSpace(data, end)
+		if pos >= len(data) || data[pos] != ':' {
+			return nil, fmt.Errorf("jsoncodec: expected ':' at offset %d", pos)
+		}
+		pos = skipSpace(data, pos+1)
+
+		dec := json.NewDecoder(bytes.NewReader(data[pos:]))
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("jsoncodec: value of %q: %w", key, err)
+		}
+		env = append(env, Field{RawKey: rawKey, Key: key, Value: value})
+		pos = skipSpace(data, pos+int(dec.InputOffset()))
+
+		if pos >= len(data) {
+			return nil, errors.New("jsoncodec: unterminated object")
+		}
+		switch data[pos] {
+		case ',':
+			pos = skipSpace(data, pos+1)
+		case '}':
+			return env, trailing(data, pos+1)
+		default:
+			return nil, fmt.Errorf("jsoncodec: expected ',' or '}' at offset %d", pos)
+		}
+	}
+}
+
+// EncodeEnvelope writes env back as a compact object. Keys and values are emitted
+// byte-for-byte, so a compact input round-trips exactly.
+func EncodeEnvelope(env Envelope) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range env {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if len(f.RawKey) > 0 {
+			buf.Write(f.RawKey)
+		} else {
+			key, _ := json.Marshal(f.Key)
+			buf.Write(key)
+		}
+		buf.WriteByte(':')
+		buf.Write(f.Value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+// Get returns the raw value of the last member named key.
+func (env Envelope) Get(key string) (json.RawMessage, bool) {
+	for i := len(env) - 1; i >= 0; i-- {
+		if env[i].Key == key {
+			return env[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Map returns the members as a map; for duplicate keys the last one wins.
+func (env Envelope) Map() map[string]json.RawMessage {
+	m := make(map[string]json.RawMessage, len(env))
+	for _, f := range env {
+		m[f.Key] = f.Value
+	}
+	return m
+}
+
+// ReplaceKey sets key to the encoding of value (a json.RawMessage is used as is)
+// wherever it occurs, keeping its position, or appends it if absent.
+func ReplaceKey(env Envelope, key string, value any) (Envelope, error) {
+	raw, ok := value.(json.RawMessage)
+	if !ok {
+		b, err := Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		raw = b
+	} else if !json.Valid(raw) {
+		return nil, fmt.Errorf("jsoncodec: invalid raw value for %q", key)
+	}
+
+	out := make(Envelope, len(env), len(env)+1)
+	copy(out, env)
+	found := false
+	for i := range out {
+		if out[i].Key == key {
+			out[i].Value = raw
+			found = true
+		}
+	}
+	if !found {
+		out = append(out, Field{Key: key, Value: raw})
+	}
+	return out, nil
+}
+
+func skipSpace(data []byte, pos int) int {
+	for pos < len(data) {
+		switch data[pos] {
+		case ' ', '\t', '\n', '\r':
+			pos++
+		default:
+			return pos
+		}
+	}
+	return pos
+}
+
+// scanString returns the offset just past the JSON string starting at pos.
+func scanString(data []byte, pos int) (int, error) {
+	if pos >= len(data) || data[pos] != '"' {
+		return 0, fmt.Errorf("jsoncodec: expected string key at offset %d", pos)
+	}
+	for i := pos + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, errors.New("jsoncodec: unterminated string")
+}
+
+func trailing(data []byte, pos int) error {
+	if skipSpace(data, pos) != len(data) {
+		return fmt.Errorf("jsoncodec: unexpected data after object at offset %d", pos)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package jsoncodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+var chatBodies = []string{
+	`{"model":"gpt-4o","messages":[{"role":"system","content":"You are terse."},{"role":"user","content":"héllo \"quoted\" <b>"}],"stream":true,"temperature":0.2}`,
+	`{"messages":[{"role":"user","content":[{"type":"text","text":"describe"},{"type":"image_url","image_url":{"url":"data:image/png;base64,iVBORw0KGgo="}}]}],"model":"openai.gpt-4o","max_tokens":256,"tools":[{"type":"function","function":{"name":"get_weather","parameters":{"type":"object","properties":{"city":{"type":"string"}}}}}]}`,
+	`{"model":"claude","stream":false,"metadata":{},"stop":null,"n":1e0}`,
+	`{}`,
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	for _, body := range chatBodies {
+		env, err := DecodeEnvelope([]byte(body))
+		if err != nil {
+			t.Fatalf("DecodeEnvelope(%s): %v", body, err)
+		}
+		if got := EncodeEnvelope(env); string(got) != body {
+			t.Errorf("round trip mismatch:\n got %s\nwant %s", got, body)
+		}
+	}
+}
+
+func TestEnvelopeTwoPhase(t *testing.T) {
+	body := []byte(chatBodies[0])
+	env, err := DecodeEnvelope(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var model string
+	raw, _ := env.Get("model")
+	if err := Unmarshal(raw, &model); err != nil || model != "gpt-4o" {
+		t.Fatalf("model = %q, err = %v", model, err)
+	}
+
+	env, err = ReplaceKey(env, "model", "gpt-4o-2024-08-06")
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err = ReplaceKey(env, "user", json.RawMessage(`"u-1"`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"model":"gpt-4o-2024-08-06","messages":[{"role":"system","content":"You are terse."},{"role":"user","content":"héllo \"quoted\" <b>"}],"stream":true,"temperature":0.2,"user":"u-1"}`
+	if got := EncodeEnvelope(env); string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	if _, err := ReplaceKey(env, "x", json.RawMessage(`{bad`)); err == nil {
+		t.Error("expected error for invalid raw value")
+	}
+}
+
+func TestEnvelopeDuplicateKeys(t *testing.T) {
+	body := `{"model":"a","stream":true,"model":"b"}`
+	env, err := DecodeEnvelope([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(EncodeEnvelope(env)) != body {
+		t.Errorf("duplicate keys should keep order, got %s", EncodeEnvelope(env))
+	}
+	if raw, _ := env.Get("model"); string(raw) != `"b"` {
+		t.Errorf("Get should return the last duplicate, got %s", raw)
+	}
+	if m := env.Map(); string(m["model"]) != `"b"` || len(m) != 2 {
+		t.Errorf("Map = %v", m)
+	}
+	env, _ = ReplaceKey(env, "model", "c")
+	if got := string(EncodeEnvelope(env)); got != `{"model":"c","stream":true,"model":"c"}` {
+		t.Errorf("ReplaceKey on duplicates = %s", got)
+	}
+}
+
+func TestEnvelopeWhitespace(t *testing.T) {
+	body := []byte("{\n  \"model\" : \"gpt-4o\",\n  \"messages\": [ {\"role\": \"user\"} ]\n}\n")
+	env, err := DecodeEnvelope(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := env.Get("messages")
+	if !bytes.Equal(raw, []byte(`[ {"role": "user"} ]`)) {
+		t.Errorf("value bytes not preserved: %s", raw)
+	}
+	if got := string(EncodeEnvelope(env)); got != `{"model":"gpt-4o","messages":[ {"role": "user"} ]}` {
+		t.Errorf("encoded = %s", got)
+	}
+}
+
+func TestDecodeEnvelopeErrors(t *testing.T) {
+	for _, body := range []string{``, `[]`, `{"a":1`, `{"a" 1}`, `{"a":1,}`, `{"a":1} x`, `{a:1}`, `{"a":}`} {
+		if _, err := DecodeEnvelope([]byte(body)); err == nil {
+			t.Errorf("DecodeEnvelope(%q): expected error", body)
+		}
+	}
+}
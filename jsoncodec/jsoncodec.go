@@ -6,8 +6,19 @@ import (
 	"github.com/bytedance/sonic"
 )
 
+// canonical mirrors encoding/json output (sorted map keys, HTML escaping) so that
+// encoded bytes are deterministic, e.g. for golden files and checksums.
+var canonical = sonic.ConfigStd
+
 func Marshal(v any) ([]byte, error) { return sonic.Marshal(v) }
 
+// MarshalCanonical encodes v deterministically with two-space indentation.
+func MarshalCanonical(v any) ([]byte, error) { return canonical.MarshalIndent(v, "", "  ") }
+
+// MarshalCanonicalCompact is MarshalCanonical without indentation; use it wherever
+// encoded bytes are hashed or compared.
+func MarshalCanonicalCompact(v any) ([]byte, error) { return canonical.Marshal(v) }
+
 func Unmarshal(data []byte, v any) error { return sonic.Unmarshal(data, v) }
 
 func UnmarshalString(data string, v any) error { return sonic.UnmarshalString(data, v) }
@@ -15,4 +26,3 @@ func UnmarshalString(data string, v any) error { return sonic.UnmarshalString(da
 func NewEncoder(w io.Writer) sonic.Encoder { return sonic.ConfigDefault.NewEncoder(w) }
 
 func NewDecoder(r io.Reader) sonic.Decoder { return sonic.ConfigDefault.NewDecoder(r) }
-
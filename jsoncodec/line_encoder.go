@@ -0,0 +1,51 @@
+package jsoncodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// LineEncoder writes one JSON value per event, e.g. SSE "data:" lines.
+// It is not safe for concurrent use.
+type LineEncoder struct {
+	w      io.Writer
+	prefix string
+	buf    bytes.Buffer
+}
+
+// NewLineEncoder returns an encoder writing prefix + payload + "\n\n" to w for each value.
+// For server-sent events use prefix "data: ".
+func NewLineEncoder(w io.Writer, prefix string) *LineEncoder {
+	return &LineEncoder{w: w, prefix: prefix}
+}
+
+// Encode marshals v without HTML escaping and writes it as a single event.
+// The payload never contains raw newlines: values whose marshaled form spans lines
+// (json.RawMessage, custom marshalers) are compacted first.
+func (e *LineEncoder) Encode(v any) error {
+	payload, err := Marshal(v)
+	if err != nil {
+		return err
+	}
+	e.buf.Reset()
+	e.buf.WriteString(e.prefix)
+	if bytes.ContainsAny(payload, "\r\n") {
+		if err := json.Compact(&e.buf, payload); err != nil {
+			return err
+		}
+	} else {
+		e.buf.Write(payload)
+	}
+	e.buf.WriteString("\n\n")
+	_, err = e.w.Write(e.buf.Bytes())
+	return err
+}
+
+// Flush flushes the underlying writer if it implements http.Flusher.
+func (e *LineEncoder) Flush() {
+	if f, ok := e.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
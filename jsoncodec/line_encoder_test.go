@@ -0,0 +1,94 @@
+package jsoncodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type chunk struct {
+	ID   int    `json:"id"`
+	Text string `json:"text"`
+}
+
+func TestLineEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewLineEncoder(&buf, "data: ")
+
+	if err := enc.Encode(chunk{ID: 1, Text: "line one\nline two\r\n<b>"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Encode(json.RawMessage("{\n  \"done\": true\n}")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "data: {\"id\":1,\"text\":\"line one\\nline two\\r\\n<b>\"}\n\n" +
+		"data: {\"done\":true}\n\n"
+	if buf.String() != want {
+		t.Errorf("got %q\nwant %q", buf.String(), want)
+	}
+
+	events := strings.Split(strings.TrimSuffix(buf.String(), "\n\n"), "\n\n")
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	for _, ev := range events {
+		if strings.Contains(ev, "\n") {
+			t.Errorf("event contains raw newline: %q", ev)
+		}
+	}
+	var got chunk
+	if err := Unmarshal([]byte(strings.TrimPrefix(events[0], "data: ")), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Text != "line one\nline two\r\n<b>" {
+		t.Errorf("round-trip text = %q", got.Text)
+	}
+}
+
+func TestLineEncoderError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewLineEncoder(&buf, "data: ").Encode(make(chan int)); err == nil {
+		t.Fatal("expected marshal error")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("nothing should be written on error, got %q", buf.String())
+	}
+}
+
+func TestLineEncoderFlush(t *testing.T) {
+	rec := httptest.NewRecorder()
+	enc := NewLineEncoder(rec, "data: ")
+	if err := enc.Encode(chunk{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	enc.Flush()
+	if !rec.Flushed {
+		t.Error("expected Flush to reach http.Flusher")
+	}
+
+	// Non-flushers are a no-op.
+	NewLineEncoder(io.Discard, "").Flush()
+}
+
+func BenchmarkLineEncoder(b *testing.B) {
+	v := chunk{ID: 42, Text: "streamed token\nwith newline"}
+	b.Run("LineEncoder", func(b *testing.B) {
+		enc := NewLineEncoder(io.Discard, "data: ")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = enc.Encode(v)
+		}
+	})
+	b.Run("Fprintf", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			payload, _ := Marshal(v)
+			_, _ = fmt.Fprintf(io.Discard, "data: %s\n\n", payload)
+		}
+	})
+}
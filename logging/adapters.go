@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Printfer is the Printf-style logger many third-party libraries accept.
+type Printfer interface {
+	Printf(format string, args ...any)
+}
+
+// PrintfLogger adapts l to Printfer: every call logs the formatted line, without a
+// trailing newline, as the message of a record at level. A nil l uses slog.Default().
+func PrintfLogger(l *slog.Logger, level slog.Level) Printfer {
+	return printfLogger{logger: orDefault(l), level: level}
+}
+
+type printfLogger struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+func (p printfLogger) Printf(format string, args ...any) {
+	ctx := context.Background()
+	if !p.logger.Enabled(ctx, p.level) {
+		return
+	}
+	p.logger.Log(ctx, p.level, strings.TrimRight(fmt.Sprintf(format, args...), "\n"))
+}
+
+// InfoErrorAdapter exposes a slog.Logger through the Info/Error methods of go-logr
+// style interfaces such as robfig/cron's cron.Logger: key-value pairs are passed
+// through as slog args, and Error logs err under "err".
+type InfoErrorAdapter struct {
+	logger *slog.Logger
+}
+
+// InfoErrorLogger returns an InfoErrorAdapter for l. A nil l uses slog.Default().
+func InfoErrorLogger(l *slog.Logger) InfoErrorAdapter {
+	return InfoErrorAdapter{logger: orDefault(l)}
+}
+
+func (a InfoErrorAdapter) Info(msg string, keysAndValues ...any) {
+	a.logger.Info(msg, keysAndValues...)
+}
+
+func (a InfoErrorAdapter) Error(err error, msg string, keysAndValues ...any) {
+	a.logger.Error(msg, append([]any{"err", err}, keysAndValues...)...)
+}
+
+func orDefault(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return slog.Default()
+	}
+	return l
+}
@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+// captureHandler records every record it handles.
+type captureHandler struct {
+	level   slog.Level
+	records []slog.Record
+}
+
+func (h *captureHandler) Enabled(_ context.Context, level slog.Level) bool { return level >= h.level }
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *captureHandler) WithGroup(string) slog.Handler      { return h }
+
+func attrsOf(r slog.Record) map[string]string {
+	out := map[string]string{}
+	r.Attrs(func(a slog.Attr) bool {
+		out[a.Key] = a.Value.String()
+		return true
+	})
+	return out
+}
+
+func TestPrintfLogger(t *testing.T) {
+	h := &captureHandler{level: slog.LevelInfo}
+	PrintfLogger(slog.New(h), slog.LevelWarn).Printf("retrying %s in %dms\n", "redis", 250)
+	PrintfLogger(slog.New(h), slog.LevelDebug).Printf("filtered %d", 1)
+
+	if len(h.records) != 1 {
+		t.Fatalf("records = %d, want 1 (debug is below the handler level)", len(h.records))
+	}
+	r := h.records[0]
+	if r.Level != slog.LevelWarn || r.Message != "retrying redis in 250ms" {
+		t.Errorf("record = %v %q", r.Level, r.Message)
+	}
+}
+
+func TestInfoErrorLogger(t *testing.T) {
+	h := &captureHandler{level: slog.LevelDebug}
+	a := InfoErrorLogger(slog.New(h))
+	a.Info("schedule", "entry", 3)
+	a.Error(errors.New("boom"), "panic", "job", "sync")
+
+	if len(h.records) != 2 {
+		t.Fatalf("records = %d, want 2", len(h.records))
+	}
+	if r := h.records[0]; r.Level != slog.LevelInfo || r.Message != "schedule" || attrsOf(r)["entry"] != "3" {
+		t.Errorf("info record = %v %q %v", r.Level, r.Message, attrsOf(r))
+	}
+	r := h.records[1]
+	attrs := attrsOf(r)
+	if r.Level != slog.LevelError || r.Message != "panic" || attrs["err"] != "boom" || attrs["job"] != "sync" {
+		t.Errorf("error record = %v %q %v", r.Level, r.Message, attrs)
+	}
+}
+
+func TestAdaptersNilLogger(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	h := &captureHandler{level: slog.LevelInfo}
+	slog.SetDefault(slog.New(h))
+	PrintfLogger(nil, slog.LevelInfo).Printf("hello")
+	InfoErrorLogger(nil).Info("world")
+	if len(h.records) != 2 {
+		t.Errorf("records = %d, want 2 via slog.Default", len(h.records))
+	}
+}
@@ -0,0 +1,157 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/rs/zerolog"
+)
+
+// consoleWriter renders zerolog JSON events through a zerolog.ConsoleWriter but keeps
+// fields in emission order; ConsoleWriter itself sorts them by key.
+type consoleWriter struct {
+	console zerolog.ConsoleWriter
+}
+
+func newConsoleWriter(console zerolog.ConsoleWriter) *consoleWriter {
+	return &consoleWriter{console: console}
+}
+
+type consoleField struct {
+	key   string
+	value json.RawMessage
+}
+
+func (w *consoleWriter) Write(p []byte) (int, error) {
+	fields, err := orderedFields(p)
+	if err != nil {
+		return w.console.Write(p)
+	}
+
+	// Let ConsoleWriter render the leading parts only; fields (including caller,
+	// which is injected last by the handler) are appended here in order.
+	var buf bytes.Buffer
+	cw := w.console
+	cw.Out = &buf
+	cw.PartsOrder = []string{zerolog.TimestampFieldName, zerolog.LevelFieldName, zerolog.MessageFieldName}
+	cw.FieldsExclude = append([]string(nil), w.console.FieldsExclude...)
+	for _, f := range fields {
+		cw.FieldsExclude = append(cw.FieldsExclude, f.key)
+	}
+	if _, err := cw.Write(p); err != nil {
+		return 0, err
+	}
+
+	line := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	out := bytes.NewBuffer(append([]byte(nil), line...))
+	for _, f := range fields {
+		if excluded(w.console.FieldsExclude, f.key) {
+			continue
+		}
+		if out.Len() > 0 {
+			out.WriteByte(' ')
+		}
+		out.WriteString(w.fieldName(f.key))
+		out.WriteString(w.fieldValue(f.key, f.value))
+	}
+	out.WriteByte('\n')
+	if _, err := w.console.Out.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *consoleWriter) fieldName(key string) string {
+	format := w.console.FormatFieldName
+	if key == zerolog.ErrorFieldName && w.console.FormatErrFieldName != nil {
+		format = w.console.FormatErrFieldName
+	}
+	if format != nil {
+		return format(key)
+	}
+	return colorize(key+"=", "36", w.console.NoColor)
+}
+
+func (w *consoleWriter) fieldValue(key string, raw json.RawMessage) string {
+	var v any = consoleValue(raw)
+	if key == zerolog.ErrorFieldName {
+		if w.console.FormatErrFieldValue != nil {
+			return w.console.FormatErrFieldValue(v)
+		}
+		return colorize(colorize(v.(string), "1", w.console.NoColor), "31", w.console.NoColor)
+	}
+	if w.console.FormatFieldValue != nil {
+		return w.console.FormatFieldValue(v)
+	}
+	return v.(string)
+}
+
+// consoleValue mirrors ConsoleWriter: strings are printed bare unless they need quoting,
+// everything else as compact JSON.
+func consoleValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if needsQuote(s) {
+			return strconv.Quote(s)
+		}
+		return s
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return string(raw)
+	}
+	return compact.String()
+}
+
+func needsQuote(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e || s[i] == ' ' || s[i] == '\\' || s[i] == '"' {
+			return true
+		}
+	}
+	return false
+}
+
+func colorize(s, code string, noColor bool) string {
+	if noColor {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+func excluded(list []string, key string) bool {
+	for _, k := range list {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// orderedFields returns the top-level fields of a JSON event in document order,
+// skipping the parts ConsoleWriter renders itself.
+func orderedFields(p []byte) ([]consoleField, error) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var fields []consoleField
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		switch key {
+		case zerolog.TimestampFieldName, zerolog.LevelFieldName, zerolog.MessageFieldName:
+			continue
+		}
+		fields = append(fields, consoleField{key: key, value: value})
+	}
+	return fields, nil
+}
@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// logOrdering emits the same record through a handler writing to w.
+func logOrdering(w io.Writer) {
+	zl := zerolog.New(w).With().Str("service", "api").Logger()
+	logger := slog.New(NewZerologHandler(zl, slog.LevelDebug, WithCaller())).
+		With("zeta", "1").
+		With("alpha", "2")
+	ctx := ContextWithRequestID(context.Background(), "req123")
+	logger.InfoContext(ctx, "hello", "mid", 3, "beta", true)
+}
+
+func jsonKeys(t *testing.T, line []byte) []string {
+	t.Helper()
+	fields, err := orderedFields(line)
+	if err != nil {
+		t.Fatalf("decode %s: %v", line, err)
+	}
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.key)
+	}
+	return keys
+}
+
+// consoleKeys parses "key=value" fields following the message, preserving order.
+func consoleKeys(line string) []string {
+	_, rest, _ := strings.Cut(line, "hello ")
+	var keys []string
+	for _, tok := range strings.Fields(rest) {
+		if k, _, ok := strings.Cut(tok, "="); ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func TestFieldOrderingParity(t *testing.T) {
+	var jsonOut bytes.Buffer
+	logOrdering(&jsonOut)
+
+	var consoleOut bytes.Buffer
+	logOrdering(newConsoleWriter(zerolog.ConsoleWriter{Out: &consoleOut, NoColor: true}))
+
+	want := []string{"service", "zeta", "alpha", "mid", "beta", RequestIDKey, CallerKey}
+	gotJSON := jsonKeys(t, jsonOut.Bytes())
+	gotConsole := consoleKeys(strings.TrimSpace(consoleOut.String()))
+
+	if strings.Join(gotJSON, ",") != strings.Join(want, ",") {
+		t.Errorf("json order = %v, want %v", gotJSON, want)
+	}
+	if strings.Join(gotConsole, ",") != strings.Join(want, ",") {
+		t.Errorf("console order = %v, want %v\n%s", gotConsole, want, consoleOut.String())
+	}
+}
+
+func TestRequestIDNotDuplicated(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewZerologHandler(zerolog.New(&buf), slog.LevelInfo))
+	ctx := ContextWithRequestID(context.Background(), "from-ctx")
+	logger.InfoContext(ctx, "msg", RequestIDKey, "explicit")
+
+	if n := strings.Count(buf.String(), RequestIDKey); n != 1 {
+		t.Fatalf("request_id appears %d times: %s", n, buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry[RequestIDKey] != "explicit" {
+		t.Errorf("request_id = %v, want explicit", entry[RequestIDKey])
+	}
+}
+
+func TestConsoleValueQuoting(t *testing.T) {
+	tests := map[string]string{
+		`"plain"`:       "plain",
+		`"two words"`:   `"two words"`,
+		`42`:            "42",
+		`{"a": [1, 2]}`: `{"a":[1,2]}`,
+		`true`:          "true",
+	}
+	for raw, want := range tests {
+		if got := consoleValue(json.RawMessage(raw)); got != want {
+			t.Errorf("consoleValue(%s) = %s, want %s", raw, got, want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+)
+
+// maxErrorChainDepth bounds how many causes are collected from a single error.
+const maxErrorChainDepth = 16
+
+// ErrAttr returns an "error" attr that renders err as {msg, type, causes}, where causes
+// lists the messages down the Unwrap chain (errors.Join branches flattened in order).
+func ErrAttr(err error) slog.Attr {
+	return slog.Any("error", errorChain{err: err})
+}
+
+type errorChain struct {
+	err error
+}
+
+func (c errorChain) LogValue() slog.Value {
+	if c.err == nil {
+		return slog.StringValue("<nil>")
+	}
+	return slog.GroupValue(
+		slog.String("msg", c.err.Error()),
+		slog.String("type", fmt.Sprintf("%T", c.err)),
+		slog.Any("causes", errorCauses(c.err)),
+	)
+}
+
+// errorDict renders err as {msg, type, causes} for ZerologHandler.
+func errorDict(err error) *zerolog.Event {
+	return zerolog.Dict().
+		Str("msg", err.Error()).
+		Str("type", fmt.Sprintf("%T", err)).
+		Strs("causes", errorCauses(err))
+}
+
+// errorCauses walks err's Unwrap chain depth-first, excluding err itself.
+func errorCauses(err error) []string {
+	causes := []string{}
+	var walk func(error)
+	walk = func(e error) {
+		var next []error
+		switch u := e.(type) {
+		case interface{ Unwrap() []error }:
+			next = u.Unwrap()
+		case interface{ Unwrap() error }:
+			if n := u.Unwrap(); n != nil {
+				next = []error{n}
+			}
+		}
+		for _, n := range next {
+			if n == nil || len(causes) >= maxErrorChainDepth {
+				continue
+			}
+			causes = append(causes, n.Error())
+			walk(n)
+		}
+	}
+	walk(err)
+	return causes
+}
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func logJSON(t *testing.T, opts []HandlerOption, args ...any) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := slog.New(NewZerologHandler(zerolog.New(&buf), slog.LevelInfo, opts...))
+	logger.Error("failed", args...)
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("decode %q: %v", buf.String(), err)
+	}
+	return out
+}
+
+func TestErrorChain_Nested(t *testing.T) {
+	base := errors.New("connection refused")
+	err := fmt.Errorf("load snapshot: %w", fmt.Errorf("redis get: %w", base))
+
+	out := logJSON(t, []HandlerOption{WithErrorChain()}, "err", err)
+	got, ok := out["err"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected object, got %v", out["err"])
+	}
+	if got["msg"] != err.Error() || got["type"] != "*fmt.wrapError" {
+		t.Fatalf("unexpected msg/type: %v", got)
+	}
+	want := []any{"redis get: connection refused", "connection refused"}
+	if !reflect.DeepEqual(got["causes"], want) {
+		t.Fatalf("causes = %v, want %v", got["causes"], want)
+	}
+}
+
+func TestErrorChain_Joined(t *testing.T) {
+	err := fmt.Errorf("validate: %w", errors.Join(errors.New("name required"), fmt.Errorf("kind: %w", errors.New("unknown"))))
+
+	out := logJSON(t, []HandlerOption{WithErrorChain()}, "err", err)
+	got := out["err"].(map[string]any)
+	want := []any{
+		"name required\nkind: unknown",
+		"name required",
+		"kind: unknown",
+		"unknown",
+	}
+	if !reflect.DeepEqual(got["causes"], want) {
+		t.Fatalf("causes = %#v, want %#v", got["causes"], want)
+	}
+}
+
+func TestErrorChain_DefaultShapeUnchanged(t *testing.T) {
+	out := logJSON(t, nil, "err", fmt.Errorf("a: %w", errors.New("b")))
+	if out["err"] != "a: b" {
+		t.Fatalf("expected plain message without option, got %v", out["err"])
+	}
+}
+
+func TestErrAttr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Error("failed", ErrAttr(fmt.Errorf("outer: %w", errors.New("inner"))))
+
+	var out struct {
+		Error struct {
+			Msg    string   `json:"msg"`
+			Type   string   `json:"type"`
+			Causes []string `json:"causes"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if out.Error.Msg != "outer: inner" || out.Error.Type != "*fmt.wrapError" || len(out.Error.Causes) != 1 || out.Error.Causes[0] != "inner" {
+		t.Fatalf("unexpected ErrAttr rendering: %+v", out.Error)
+	}
+}
+
+func TestErrAttr_ZerologHandler(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		typ    string
+		causes []any
+	}{
+		{
+			name:   "wrapped",
+			err:    fmt.Errorf("outer: %w", fmt.Errorf("middle: %w", errors.New("inner"))),
+			typ:    "*fmt.wrapError",
+			causes: []any{"middle: inner", "inner"},
+		},
+		{
+			name:   "joined",
+			err:    errors.Join(errors.New("a"), fmt.Errorf("b: %w", errors.New("c"))),
+			typ:    "*errors.joinError",
+			causes: []any{"a", "b: c", "c"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// No WithErrorChain: ErrAttr alone must produce the nested object.
+			out := logJSON(t, nil, ErrAttr(tc.err))
+			got, ok := out["error"].(map[string]any)
+			if !ok {
+				t.Fatalf("expected nested object, got %v", out)
+			}
+			if got["msg"] != tc.err.Error() || got["type"] != tc.typ {
+				t.Fatalf("unexpected msg/type: %v", got)
+			}
+			if !reflect.DeepEqual(got["causes"], tc.causes) {
+				t.Fatalf("causes = %#v, want %#v", got["causes"], tc.causes)
+			}
+			if _, flat := out["error.msg"]; flat {
+				t.Fatalf("error attr was flattened: %v", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package fields defines the log field names shared by CP and DP services, so logs
+// can be joined across services without per-service spellings (bindingKey vs binding_key).
+package fields
+
+import (
+	"log/slog"
+
+	"github.com/ez-api/foundation/routing"
+)
+
+// Standard log field names. All are snake_case.
+const (
+	FieldRequestID     = "request_id"
+	FieldBindingKey    = "binding_key"
+	FieldNamespace     = "namespace"
+	FieldPublicModel   = "public_model"
+	FieldUpstreamModel = "upstream_model"
+	FieldProviderID    = "provider_id"
+	FieldGroupID       = "group_id"
+	FieldJobName       = "job_name"
+	FieldRunID         = "run_id"
+)
+
+// RequestID returns a request_id attr.
+func RequestID(id string) slog.Attr {
+	return slog.String(FieldRequestID, id)
+}
+
+// BindingKey returns a binding_key attr for ref ("namespace.public_model").
+func BindingKey(ref routing.ModelRef) slog.Attr {
+	return slog.String(FieldBindingKey, ref.Key())
+}
+
+// Namespace returns a namespace attr.
+func Namespace(ns string) slog.Attr {
+	return slog.String(FieldNamespace, ns)
+}
+
+// PublicModel returns a public_model attr.
+func PublicModel(model string) slog.Attr {
+	return slog.String(FieldPublicModel, model)
+}
+
+// UpstreamModel returns an upstream_model attr.
+func UpstreamModel(model string) slog.Attr {
+	return slog.String(FieldUpstreamModel, model)
+}
+
+// ProviderID returns a provider_id attr.
+func ProviderID(id string) slog.Attr {
+	return slog.String(FieldProviderID, id)
+}
+
+// GroupID returns a group_id attr.
+func GroupID(id uint) slog.Attr {
+	return slog.Uint64(FieldGroupID, uint64(id))
+}
+
+// JobName returns a job_name attr.
+func JobName(name string) slog.Attr {
+	return slog.String(FieldJobName, name)
+}
+
+// RunID returns a run_id attr for one scheduler job invocation.
+func RunID(id string) slog.Attr {
+	return slog.String(FieldRunID, id)
+}
@@ -0,0 +1,47 @@
+package fields
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ez-api/foundation/routing"
+)
+
+var all = []string{
+	FieldRequestID,
+	FieldBindingKey,
+	FieldNamespace,
+	FieldPublicModel,
+	FieldUpstreamModel,
+	FieldProviderID,
+	FieldGroupID,
+	FieldJobName,
+	FieldRunID,
+}
+
+func TestFieldNamesUniqueSnakeCase(t *testing.T) {
+	snake := regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	seen := map[string]bool{}
+	for _, name := range all {
+		if !snake.MatchString(name) {
+			t.Errorf("%q is not snake_case", name)
+		}
+		if seen[name] {
+			t.Errorf("duplicate field name %q", name)
+		}
+		seen[name] = true
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	ref := routing.ModelRef{Namespace: "acme", PublicModel: "gpt-4o"}
+	if a := BindingKey(ref); a.Key != FieldBindingKey || a.Value.String() != "acme.gpt-4o" {
+		t.Errorf("BindingKey = %v", a)
+	}
+	if a := GroupID(7); a.Key != FieldGroupID || a.Value.Uint64() != 7 {
+		t.Errorf("GroupID = %v", a)
+	}
+	if a := JobName("sync"); a.Key != FieldJobName || a.Value.String() != "sync" {
+		t.Errorf("JobName = %v", a)
+	}
+}
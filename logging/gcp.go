@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Console formats accepted in Options.Format.
+const (
+	FormatConsole = "console"
+	// FormatGCP writes JSON with the field names Google Cloud Logging understands:
+	// severity (DEBUG, INFO, WARNING, ...), timestamp (RFC3339Nano) and message.
+	FormatGCP = "gcp"
+)
+
+// gcpSeverity maps zerolog level names to Cloud Logging severities.
+var gcpSeverity = map[string]string{
+	zerolog.LevelTraceValue: "DEBUG",
+	zerolog.LevelDebugValue: "DEBUG",
+	zerolog.LevelInfoValue:  "INFO",
+	zerolog.LevelWarnValue:  "WARNING",
+	zerolog.LevelErrorValue: "ERROR",
+	zerolog.LevelFatalValue: "CRITICAL",
+	zerolog.LevelPanicValue: "ALERT",
+}
+
+// gcpWriter rewrites zerolog JSON events for Cloud Logging, keeping all other fields
+// in emission order. The timestamp is taken when the event is written, since zerolog's
+// own time field only has the (global) TimeFieldFormat precision.
+type gcpWriter struct {
+	out io.Writer
+	now func() time.Time
+}
+
+func newGCPWriter(out io.Writer) *gcpWriter {
+	return &gcpWriter{out: out, now: time.Now}
+}
+
+func (w *gcpWriter) Write(p []byte) (int, error) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return w.out.Write(p)
+	}
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return w.out.Write(p)
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return w.out.Write(p)
+		}
+		switch key {
+		case zerolog.LevelFieldName:
+			var level string
+			_ = json.Unmarshal(value, &level)
+			severity, ok := gcpSeverity[level]
+			if !ok {
+				severity = "DEFAULT"
+			}
+			key, value = "severity", mustJSON(severity)
+		case zerolog.TimestampFieldName:
+			key, value = "timestamp", mustJSON(w.now().UTC().Format(time.RFC3339Nano))
+		case zerolog.MessageFieldName:
+			key = "message"
+		}
+		if out.Len() > 1 {
+			out.WriteByte(',')
+		}
+		out.Write(mustJSON(key))
+		out.WriteByte(':')
+		out.Write(value)
+	}
+	out.WriteString("}\n")
+	if _, err := w.out.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func mustJSON(s string) json.RawMessage {
+	b, _ := json.Marshal(s)
+	return b
+}
@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGCPFormat(t *testing.T) {
+	t.Setenv(LevelEnv, "")
+	prev := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(prev)
+
+	var out bytes.Buffer
+	sl, zl, _, err := build(Options{Service: "api", Level: "debug", Format: "GCP"}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	zl = zl.Level(zerolog.TraceLevel)
+
+	sl.Debug("debug record", "model", "gpt-4o")
+	sl.Info("info record")
+	sl.Warn("warn record")
+	sl.Error("error record")
+	zl.Trace().Msg("trace record")
+	zl.WithLevel(zerolog.FatalLevel).Msg("fatal record")
+	zl.WithLevel(zerolog.PanicLevel).Msg("panic record")
+	zl.Log().Msg("no level record")
+
+	want := []struct{ message, severity string }{
+		{"debug record", "DEBUG"},
+		{"info record", "INFO"},
+		{"warn record", "WARNING"},
+		{"error record", "ERROR"},
+		{"trace record", "DEBUG"},
+		{"fatal record", "CRITICAL"},
+		{"panic record", "ALERT"},
+		{"no level record", ""},
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line %d is not JSON: %q", i, line)
+		}
+		if entry["message"] != want[i].message {
+			t.Errorf("line %d message = %v, want %q", i, entry["message"], want[i].message)
+		}
+		if sev, _ := entry["severity"].(string); sev != want[i].severity {
+			t.Errorf("%s: severity = %q, want %q", want[i].message, sev, want[i].severity)
+		}
+		ts, _ := entry["timestamp"].(string)
+		if _, err := time.Parse(time.RFC3339Nano, ts); err != nil {
+			t.Errorf("%s: timestamp %q is not RFC3339Nano", want[i].message, ts)
+		}
+		for _, gone := range []string{"level", "time"} {
+			if _, ok := entry[gone]; ok {
+				t.Errorf("%s: zerolog field %q should be renamed", want[i].message, gone)
+			}
+		}
+		if entry["service"] != "api" {
+			t.Errorf("%s: service = %v", want[i].message, entry["service"])
+		}
+	}
+	if !strings.Contains(lines[0], `"model":"gpt-4o"`) {
+		t.Errorf("attrs should pass through unchanged: %s", lines[0])
+	}
+	if cfg := Resolve(Options{Format: "gcp"}); cfg.Format != FormatGCP {
+		t.Errorf("Resolve format = %q, want %q", cfg.Format, FormatGCP)
+	}
+}
+
+func TestGCPWriterTimestamp(t *testing.T) {
+	var out bytes.Buffer
+	w := newGCPWriter(&out)
+	w.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.FixedZone("x", 3600)) }
+	if _, err := w.Write([]byte(`{"level":"info","time":"2026-01-02T04:04:05+01:00","message":"m"}` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), `{"severity":"INFO","timestamp":"2026-01-02T02:04:05.123456789Z","message":"m"}`+"\n"; got != want {
+		t.Errorf("got %s want %s", got, want)
+	}
+}
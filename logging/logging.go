@@ -1,6 +1,8 @@
 package logging
 
 import (
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
@@ -9,29 +11,160 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// LevelEnv overrides Options.Level when set.
+const LevelEnv = "EZ_LOG_LEVEL"
+
 type Options struct {
 	Service string
+	Version string
+	// Level is the minimum level (debug, info, warn, error); EZ_LOG_LEVEL takes precedence.
+	Level string
+	// Format is the stdout format: FormatConsole (default) or FormatGCP.
+	Format string
+	// ErrorChain renders error attrs as {msg, type, causes}; see WithErrorChain.
+	ErrorChain bool
+	// TeeFile, when set, additionally appends every record as JSON to this file
+	// (created if missing), whatever the console format. Intended for local development.
+	// Only Setup supports it; New panics when it is set.
+	TeeFile string
+}
+
+// Source tells where an effective setting came from.
+type Source string
+
+const (
+	SourceEnv     Source = "env"
+	SourceOption  Source = "option"
+	SourceDefault Source = "default"
+)
+
+// Config is the effective logger configuration, as logged by New and Setup in the
+// "logger initialized" record.
+type Config struct {
+	Level         slog.Level
+	LevelSource   Source
+	Format        string // console format on stdout; the tee file is always JSON
+	Output        string // "stdout", or "stdout+file:<path>" with TeeFile
+	Service       string
+	ServiceSource Source
+	Version       string
+	VersionSource Source
+}
+
+// Resolve returns the configuration New and Setup would apply for opts.
+func Resolve(opts Options) Config {
+	cfg := Config{
+		Level:         slog.LevelInfo,
+		LevelSource:   SourceDefault,
+		Format:        FormatConsole,
+		Output:        "stdout",
+		Service:       strings.TrimSpace(opts.Service),
+		ServiceSource: SourceDefault,
+		Version:       strings.TrimSpace(opts.Version),
+		VersionSource: SourceDefault,
+	}
+	if env := strings.TrimSpace(os.Getenv(LevelEnv)); env != "" {
+		cfg.Level, cfg.LevelSource = parseLevel(env), SourceEnv
+	} else if lvl := strings.TrimSpace(opts.Level); lvl != "" {
+		cfg.Level, cfg.LevelSource = parseLevel(lvl), SourceOption
+	}
+	if strings.EqualFold(strings.TrimSpace(opts.Format), FormatGCP) {
+		cfg.Format = FormatGCP
+	}
+	if path := strings.TrimSpace(opts.TeeFile); path != "" {
+		cfg.Output = "stdout+file:" + path
+	}
+	if cfg.Service != "" {
+		cfg.ServiceSource = SourceOption
+	}
+	if cfg.Version != "" {
+		cfg.VersionSource = SourceOption
+	}
+	return cfg
+}
+
+// attrs returns the "logger initialized" fields. Service and version are already on
+// every record, and "level" is taken by zerolog, hence log_level.
+func (c Config) attrs() []any {
+	return []any{
+		"log_level", c.Level.String(),
+		"log_level_source", c.LevelSource,
+		"format", c.Format,
+		"output", c.Output,
+		"service_source", c.ServiceSource,
+		"version_source", c.VersionSource,
+	}
 }
 
+// New builds the process logger, installs it as the slog default and logs its
+// effective configuration (see Resolve) at info. It is Setup without TeeFile: New
+// cannot report the error from opening the file or hand it back, so it panics when
+// Options.TeeFile is set. Use Setup to tee records to a file.
 func New(opts Options) (*slog.Logger, zerolog.Logger) {
-	level := parseLevel(strings.TrimSpace(os.Getenv("EZ_LOG_LEVEL")))
+	if strings.TrimSpace(opts.TeeFile) != "" {
+		panic("logging: New does not support Options.TeeFile; use Setup")
+	}
+	sl, zl, _, _ := Setup(opts) // cannot fail without a tee file
+	return sl, zl
+}
+
+// Setup is New with TeeFile support. It returns the error from opening TeeFile, and a
+// Closer for the file that the caller should close on shutdown (a no-op without TeeFile).
+func Setup(opts Options) (*slog.Logger, zerolog.Logger, io.Closer, error) {
+	return setup(opts, os.Stdout)
+}
+
+func setup(opts Options, stdout io.Writer) (*slog.Logger, zerolog.Logger, io.Closer, error) {
+	sl, zl, closer, err := build(opts, stdout)
+	if err != nil {
+		return nil, zerolog.Nop(), nil, err
+	}
+	slog.SetDefault(sl)
+	sl.Info("logger initialized", Resolve(opts).attrs()...)
+	return sl, zl, closer, nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+func build(opts Options, stdout io.Writer) (*slog.Logger, zerolog.Logger, io.Closer, error) {
+	cfg := Resolve(opts)
+	level := cfg.Level
 	zerolog.SetGlobalLevel(toZerologLevel(level))
 
-	output := zerolog.ConsoleWriter{
-		Out:        os.Stdout,
+	var output io.Writer = newConsoleWriter(zerolog.ConsoleWriter{
+		Out:        stdout,
 		TimeFormat: time.RFC3339,
+	})
+	if cfg.Format == FormatGCP {
+		output = newGCPWriter(stdout)
+	}
+	var closer io.Closer = nopCloser{}
+	if path := strings.TrimSpace(opts.TeeFile); path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, zerolog.Nop(), nil, fmt.Errorf("open log tee file: %w", err)
+		}
+		output = zerolog.MultiLevelWriter(output, f)
+		closer = f
 	}
 
-	zl := zerolog.New(output).
+	zc := zerolog.New(output).
 		Level(toZerologLevel(level)).
 		With().
 		Timestamp().
-		Str("service", strings.TrimSpace(opts.Service)).
-		Logger()
+		Str("service", cfg.Service)
+	if cfg.Version != "" {
+		zc = zc.Str("version", cfg.Version)
+	}
+	zl := zc.Logger()
 
-	sl := slog.New(NewZerologHandler(zl, level))
-	slog.SetDefault(sl)
-	return sl, zl
+	var handlerOpts []HandlerOption
+	if opts.ErrorChain {
+		handlerOpts = append(handlerOpts, WithErrorChain())
+	}
+	return slog.New(NewZerologHandler(zl, level, handlerOpts...)), zl, closer, nil
 }
 
 func parseLevel(raw string) slog.Level {
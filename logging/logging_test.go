@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTeeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.log")
+	var console bytes.Buffer
+	sl, _, closer, err := build(Options{Service: "api", TeeFile: path}, &console)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sl.Info("tee record", "model", "gpt-4o")
+	if err := closer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := closer.Close(); err == nil {
+		t.Error("closer should close the tee file")
+	}
+
+	if out := console.String(); !strings.Contains(out, "tee record") || !strings.Contains(out, "model=") || strings.HasPrefix(strings.TrimSpace(out), "{") {
+		t.Errorf("console should be human formatted, got %q", out)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(raw), &entry); err != nil {
+		t.Fatalf("tee file should hold JSON, got %q: %v", raw, err)
+	}
+	if entry["message"] != "tee record" || entry["model"] != "gpt-4o" || entry["service"] != "api" {
+		t.Errorf("unexpected tee entry: %v", entry)
+	}
+}
+
+func TestTeeFileOpenError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing-dir", "dev.log")
+	if _, _, _, err := build(Options{TeeFile: path}, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected error for unopenable tee file")
+	}
+}
+
+func TestNewRejectsTeeFile(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected New to panic when TeeFile is set")
+		}
+	}()
+	New(Options{TeeFile: filepath.Join(t.TempDir(), "dev.log")})
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	t.Setenv(LevelEnv, "")
+	cfg := Resolve(Options{Service: " api ", Level: "warn", TeeFile: "dev.log"})
+	want := Config{
+		Level: slog.LevelWarn, LevelSource: SourceOption,
+		Format: "console", Output: "stdout+file:dev.log",
+		Service: "api", ServiceSource: SourceOption,
+		VersionSource: SourceDefault,
+	}
+	if cfg != want {
+		t.Errorf("Resolve = %+v, want %+v", cfg, want)
+	}
+
+	t.Setenv(LevelEnv, "debug")
+	if cfg := Resolve(Options{Level: "error"}); cfg.Level != slog.LevelDebug || cfg.LevelSource != SourceEnv {
+		t.Errorf("env should override option: %+v", cfg)
+	}
+	t.Setenv(LevelEnv, "")
+	if cfg := Resolve(Options{}); cfg.Level != slog.LevelInfo || cfg.LevelSource != SourceDefault {
+		t.Errorf("default level: %+v", cfg)
+	}
+}
+
+func TestSetupLogsSummary(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	t.Setenv(LevelEnv, "debug")
+	var out bytes.Buffer
+	_, _, closer, err := setup(Options{Service: "api", Version: "1.2.3", Level: "error"}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := closer.Close(); err != nil {
+		t.Errorf("Close without tee file: %v", err)
+	}
+	line := regexp.MustCompile(`\x1b\[[0-9;]*m`).ReplaceAllString(out.String(), "")
+	for _, want := range []string{"logger initialized", "INF", "log_level=DEBUG", "log_level_source=env", "format=console", "output=stdout", "service=api", "service_source=option", "version=1.2.3", "version_source=option"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("summary missing %q: %s", want, line)
+		}
+	}
+}
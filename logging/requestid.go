@@ -0,0 +1,17 @@
+package logging
+
+import "context"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID attaches a request id to ctx. ZerologHandler adds it to records
+// logged with ctx, and TapHandler to the records it flushes.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request id attached by ContextWithRequestID.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,145 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// DefaultTapLimit bounds how many records a single tapped request buffers.
+const DefaultTapLimit = 256
+
+type tapKey struct{}
+
+// ContextWithTap marks ctx so that a TapHandler buffers its records instead of emitting them.
+// Call CommitSuccess or CommitFailure when the request finishes.
+//
+// The buffer is owned by the returned context: if neither commit is called, it is
+// released together with the context, so there is no global state to sweep.
+func ContextWithTap(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tapKey{}, &tap{limit: DefaultTapLimit})
+}
+
+// CommitSuccess discards the records buffered for ctx.
+func CommitSuccess(ctx context.Context) {
+	if t := tapFrom(ctx); t != nil {
+		t.commit()
+	}
+}
+
+// CommitFailure flushes the records buffered for ctx to the wrapped handlers,
+// each tagged with the request id from ContextWithRequestID.
+func CommitFailure(ctx context.Context) {
+	t := tapFrom(ctx)
+	if t == nil {
+		return
+	}
+	entries, dropped := t.commit()
+	reqID := RequestIDFromContext(ctx)
+	for _, e := range entries {
+		rec := e.record
+		if reqID != "" {
+			// Injected fields go after the record's own attrs (see ZerologHandler).
+			rec = e.record.Clone()
+			rec.AddAttrs(slog.String(RequestIDKey, reqID))
+		}
+		_ = e.handler.Handle(ctx, rec)
+	}
+	if dropped > 0 && len(entries) > 0 {
+		last := entries[len(entries)-1]
+		rec := slog.NewRecord(last.record.Time, slog.LevelWarn, "log tap buffer overflow", 0)
+		rec.AddAttrs(slog.Int("dropped", dropped))
+		if reqID != "" {
+			rec.AddAttrs(slog.String(RequestIDKey, reqID))
+		}
+		_ = last.handler.Handle(ctx, rec)
+	}
+}
+
+// TapHandler wraps a handler and buffers records of tapped contexts (see ContextWithTap).
+// While a context is tapped, records of every level are captured; on CommitFailure they
+// are passed to the inner handler regardless of its level, so the inner backend must be
+// able to emit debug records for the extra detail to show up.
+// Records of untapped or already committed contexts go straight to the inner handler.
+type TapHandler struct {
+	inner slog.Handler
+}
+
+func NewTapHandler(inner slog.Handler) *TapHandler {
+	return &TapHandler{inner: inner}
+}
+
+func (h *TapHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if t := tapFrom(ctx); t != nil && t.active() {
+		return true
+	}
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *TapHandler) Handle(ctx context.Context, record slog.Record) error {
+	if t := tapFrom(ctx); t != nil && t.add(h.inner, record) {
+		return nil
+	}
+	if !h.inner.Enabled(ctx, record.Level) {
+		return nil
+	}
+	return h.inner.Handle(ctx, record)
+}
+
+func (h *TapHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &TapHandler{inner: h.inner.WithAttrs(attrs)}
+}
+
+func (h *TapHandler) WithGroup(name string) slog.Handler {
+	return &TapHandler{inner: h.inner.WithGroup(name)}
+}
+
+type tapEntry struct {
+	handler slog.Handler
+	record  slog.Record
+}
+
+type tap struct {
+	mu        sync.Mutex
+	limit     int
+	entries   []tapEntry
+	dropped   int
+	committed bool
+}
+
+func tapFrom(ctx context.Context) *tap {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(tapKey{}).(*tap)
+	return t
+}
+
+func (t *tap) active() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return !t.committed
+}
+
+// add buffers the record and reports whether it was consumed by the tap.
+func (t *tap) add(h slog.Handler, record slog.Record) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.committed {
+		return false
+	}
+	if len(t.entries) >= t.limit {
+		t.dropped++
+		return true
+	}
+	t.entries = append(t.entries, tapEntry{handler: h, record: record.Clone()})
+	return true
+}
+
+func (t *tap) commit() ([]tapEntry, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entries, dropped := t.entries, t.dropped
+	t.entries, t.dropped, t.committed = nil, 0, true
+	return entries, dropped
+}
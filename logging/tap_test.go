@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func tapMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := ContextWithRequestID(r.Context(), r.Header.Get("X-Request-ID"))
+		ctx = ContextWithTap(ctx)
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+		if rec.status >= http.StatusInternalServerError {
+			CommitFailure(ctx)
+		} else {
+			CommitSuccess(ctx)
+		}
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func TestTapHandler_Middleware(t *testing.T) {
+	var out bytes.Buffer
+	inner := slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := slog.New(NewTapHandler(inner)).With("component", "api")
+
+	srv := tapMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.DebugContext(r.Context(), "loaded binding", "model", "m")
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+
+	for _, path := range []string{"/ok", "/fail"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Request-ID", "req"+strings.TrimPrefix(path, "/"))
+		srv.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected only the failed request to be flushed, got %d lines:\n%s", len(lines), out.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if entry["request_id"] != "reqfail" || entry["msg"] != "loaded binding" || entry["component"] != "api" || entry["level"] != "DEBUG" {
+		t.Fatalf("unexpected flushed record: %v", entry)
+	}
+}
+
+func TestTapHandler_BoundedAndPassthrough(t *testing.T) {
+	var out bytes.Buffer
+	inner := slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger := slog.New(NewTapHandler(inner))
+
+	ctx := ContextWithTap(context.Background())
+	for i := 0; i < DefaultTapLimit+10; i++ {
+		logger.InfoContext(ctx, "step")
+	}
+	if out.Len() != 0 {
+		t.Fatal("tapped records must be buffered")
+	}
+	CommitFailure(ctx)
+	if n := strings.Count(out.String(), `"msg":"step"`); n != DefaultTapLimit {
+		t.Fatalf("flushed %d records, want %d", n, DefaultTapLimit)
+	}
+	if !strings.Contains(out.String(), `"dropped":10`) {
+		t.Fatalf("expected overflow notice, got %s", out.String())
+	}
+	if strings.Contains(out.String(), RequestIDKey) {
+		t.Errorf("no request id in context, none expected in records: %s", out.String())
+	}
+
+	out.Reset()
+	ctx = ContextWithTap(ContextWithRequestID(context.Background(), "req-7"))
+	for i := 0; i < DefaultTapLimit+1; i++ {
+		logger.InfoContext(ctx, "step")
+	}
+	CommitFailure(ctx)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if last := lines[len(lines)-1]; !strings.Contains(last, "log tap buffer overflow") || !strings.Contains(last, `"request_id":"req-7"`) {
+		t.Errorf("overflow notice should carry the request id: %s", last)
+	}
+
+	out.Reset()
+	logger.InfoContext(ctx, "after commit")
+	logger.DebugContext(ctx, "filtered")
+	logger.Info("untapped")
+	if got := strings.Count(out.String(), "\n"); got != 2 || strings.Contains(out.String(), "filtered") {
+		t.Fatalf("expected passthrough after commit, got %s", out.String())
+	}
+}
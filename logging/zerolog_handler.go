@@ -4,43 +4,96 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"runtime"
+	"strconv"
 	"strings"
 
+	"github.com/ez-api/foundation/logging/fields"
 	"github.com/rs/zerolog"
 )
 
+// Keys of the fields ZerologHandler injects after all attrs.
+const (
+	RequestIDKey = fields.FieldRequestID
+	CallerKey    = "caller"
+)
+
+// ZerologHandler is a slog.Handler backed by a zerolog.Logger.
+//
+// Field order is the same in every output mode: fields bound on the zerolog logger
+// (e.g. service), then handler attrs in WithAttrs bind order, then record attrs in
+// emission order, then injected fields last: request_id (from ContextWithRequestID,
+// unless an attr already set it) and caller (with WithCaller). Console output
+// created by New preserves this order instead of sorting keys.
 type ZerologHandler struct {
-	logger zerolog.Logger
-	level  slog.Level
-	attrs  []slog.Attr
-	groups []string
+	logger     zerolog.Logger
+	level      slog.Level
+	attrs      []slog.Attr
+	groups     []string
+	errorChain bool
+	caller     bool
+}
+
+// HandlerOption configures a ZerologHandler.
+type HandlerOption func(*ZerologHandler)
+
+// WithErrorChain renders error values as an object {msg, type, causes} instead of a
+// plain message string. It changes the field shape, so it is opt-in.
+func WithErrorChain() HandlerOption {
+	return func(h *ZerologHandler) {
+		h.errorChain = true
+	}
+}
+
+// WithCaller injects the caller's file:line as the last field.
+func WithCaller() HandlerOption {
+	return func(h *ZerologHandler) {
+		h.caller = true
+	}
 }
 
-func NewZerologHandler(logger zerolog.Logger, level slog.Level) *ZerologHandler {
-	return &ZerologHandler{
+func NewZerologHandler(logger zerolog.Logger, level slog.Level, opts ...HandlerOption) *ZerologHandler {
+	h := &ZerologHandler{
 		logger: logger,
 		level:  level,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 func (h *ZerologHandler) Enabled(_ context.Context, level slog.Level) bool {
 	return level >= h.level
 }
 
-func (h *ZerologHandler) Handle(_ context.Context, record slog.Record) error {
+func (h *ZerologHandler) Handle(ctx context.Context, record slog.Record) error {
 	event := h.eventFor(record.Level)
 	if event == nil {
 		return nil
 	}
 
+	hasRequestID := false
 	for _, attr := range h.attrs {
+		hasRequestID = hasRequestID || attr.Key == RequestIDKey
 		h.addAttr(event, h.key(attr.Key), attr.Value)
 	}
 	record.Attrs(func(attr slog.Attr) bool {
+		hasRequestID = hasRequestID || attr.Key == RequestIDKey
 		h.addAttr(event, h.key(attr.Key), attr.Value)
 		return true
 	})
 
+	if ctx != nil && !hasRequestID {
+		if id := RequestIDFromContext(ctx); id != "" {
+			event.Str(RequestIDKey, id)
+		}
+	}
+	if h.caller && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		event.Str(CallerKey, frame.File+":"+strconv.Itoa(frame.Line))
+	}
+
 	event.Msg(record.Message)
 	return nil
 }
@@ -96,6 +149,15 @@ func (h *ZerologHandler) addAttr(event *zerolog.Event, key string, value slog.Va
 		return
 	}
 
+	// ErrAttr values resolve to a group, which would be flattened below; emit
+	// them as a nested object, like WithErrorChain.
+	if value.Kind() == slog.KindLogValuer {
+		if c, ok := value.Any().(errorChain); ok && c.err != nil {
+			event.Dict(key, errorDict(c.err))
+			return
+		}
+	}
+
 	value = value.Resolve()
 
 	switch value.Kind() {
@@ -121,6 +183,10 @@ func (h *ZerologHandler) addAttr(event *zerolog.Event, key string, value slog.Va
 	default:
 		anyValue := value.Any()
 		if err, ok := anyValue.(error); ok {
+			if h.errorChain {
+				event.Dict(key, errorDict(err))
+				return
+			}
 			event.AnErr(key, err)
 			return
 		}
@@ -0,0 +1,167 @@
+package modelcap
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrLoaderPanicked is returned to lookups that were waiting on a load that panicked.
+var ErrLoaderPanicked = errors.New("modelcap: loader panicked")
+
+// Loader looks up a model by bindingKey. found is false (with a nil error) for
+// keys that do not exist.
+type Loader func(key string) (m Model, found bool, err error)
+
+// CacheOptions configures a CachedLookup. Zero fields take the defaults noted.
+type CacheOptions struct {
+	Size        int           // max cached keys, hits and misses together; default 10000
+	TTL         time.Duration // lifetime of found models; default 1m
+	NegativeTTL time.Duration // lifetime of "not found" results; default 10s
+}
+
+// CachedLookup wraps a Loader with a bounded LRU cache that also remembers missing
+// keys, so repeated lookups of typos or deleted models stay in memory. Concurrent
+// lookups of the same uncached key share one load. Errors are never cached.
+// Call Invalidate or InvalidateAll when the catalog changes.
+type CachedLookup struct {
+	load Loader
+	opts CacheOptions
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // front = most recently used
+	items   map[string]*list.Element
+	flights map[string]*lookupFlight
+}
+
+type cacheEntry struct {
+	key     string
+	model   Model
+	found   bool
+	expires time.Time
+}
+
+type lookupFlight struct {
+	done  chan struct{}
+	model Model
+	found bool
+	err   error
+}
+
+func NewCachedLookup(load Loader, opts CacheOptions) *CachedLookup {
+	if opts.Size <= 0 {
+		opts.Size = 10000
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = time.Minute
+	}
+	if opts.NegativeTTL <= 0 {
+		opts.NegativeTTL = 10 * time.Second
+	}
+	return &CachedLookup{
+		load:    load,
+		opts:    opts,
+		now:     time.Now,
+		order:   list.New(),
+		items:   make(map[string]*list.Element),
+		flights: make(map[string]*lookupFlight),
+	}
+}
+
+// Get returns the model for key from the cache, loading it on a miss.
+func (c *CachedLookup) Get(key string) (Model, bool, error) {
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*cacheEntry)
+		if c.now().Before(e.expires) {
+			c.order.MoveToFront(el)
+			c.mu.Unlock()
+			return e.model, e.found, nil
+		}
+		c.removeLocked(el)
+	}
+	if f, ok := c.flights[key]; ok {
+		c.mu.Unlock()
+		<-f.done
+		return f.model, f.found, f.err
+	}
+	f := &lookupFlight{done: make(chan struct{})}
+	c.flights[key] = f
+	c.mu.Unlock()
+
+	c.runFlight(key, f)
+	return f.model, f.found, f.err
+}
+
+// runFlight loads key into f and wakes its waiters. If the loader panics, waiters
+// get ErrLoaderPanicked and the panic continues in the loading goroutine.
+func (c *CachedLookup) runFlight(key string, f *lookupFlight) {
+	completed := false
+	defer func() {
+		var r any
+		if !completed {
+			r = recover()
+			f.model, f.found, f.err = Model{}, false, fmt.Errorf("%w: %v", ErrLoaderPanicked, r)
+		}
+		c.mu.Lock()
+		// An Invalidate during the load drops the flight; its result may be stale.
+		if c.flights[key] == f {
+			delete(c.flights, key)
+			if f.err == nil {
+				c.storeLocked(key, f.model, f.found)
+			}
+		}
+		c.mu.Unlock()
+		close(f.done)
+		if !completed {
+			panic(r)
+		}
+	}()
+	f.model, f.found, f.err = c.load(key)
+	completed = true
+}
+
+// Invalidate drops key, including any load of it in progress.
+func (c *CachedLookup) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.removeLocked(el)
+	}
+	delete(c.flights, key)
+}
+
+// InvalidateAll empties the cache, e.g. after a catalog swap.
+func (c *CachedLookup) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.items)
+	clear(c.flights)
+}
+
+// Len returns the number of cached keys, expired entries included.
+func (c *CachedLookup) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *CachedLookup) storeLocked(key string, m Model, found bool) {
+	ttl := c.opts.TTL
+	if !found {
+		ttl = c.opts.NegativeTTL
+	}
+	if c.order.Len() >= c.opts.Size {
+		c.removeLocked(c.order.Back())
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, model: m, found: found, expires: c.now().Add(ttl)})
+}
+
+func (c *CachedLookup) removeLocked(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*cacheEntry).key)
+}
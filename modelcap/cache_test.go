@@ -0,0 +1,201 @@
+package modelcap
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeNow struct{ t time.Time }
+
+func (f *fakeNow) now() time.Time          { return f.t }
+func (f *fakeNow) advance(d time.Duration) { f.t = f.t.Add(d) }
+
+func countingLoader(models map[string]Model, calls *atomic.Int32) Loader {
+	return func(key string) (Model, bool, error) {
+		calls.Add(1)
+		m, ok := models[key]
+		return m, ok, nil
+	}
+}
+
+func TestCachedLookupNegativeExpiry(t *testing.T) {
+	var calls atomic.Int32
+	models := map[string]Model{"ns.gpt-4o": {Name: "gpt-4o"}}
+	c := NewCachedLookup(countingLoader(models, &calls), CacheOptions{TTL: time.Minute, NegativeTTL: 5 * time.Second})
+	clock := &fakeNow{t: time.Unix(1_700_000_000, 0)}
+	c.now = clock.now
+
+	for i := 0; i < 3; i++ {
+		if _, found, err := c.Get("ns.typo"); found || err != nil {
+			t.Fatalf("Get(typo) = %v, %v", found, err)
+		}
+		if m, found, _ := c.Get("ns.gpt-4o"); !found || m.Name != "gpt-4o" {
+			t.Fatalf("Get(gpt-4o) = %+v, %v", m, found)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("loads = %d, want 2 (hit and miss cached)", n)
+	}
+
+	clock.advance(6 * time.Second)
+	c.Get("ns.typo")
+	c.Get("ns.gpt-4o")
+	if n := calls.Load(); n != 3 {
+		t.Errorf("loads = %d, want 3 (only the negative entry expired)", n)
+	}
+}
+
+func TestCachedLookupErrorsNotCached(t *testing.T) {
+	var calls atomic.Int32
+	c := NewCachedLookup(func(key string) (Model, bool, error) {
+		calls.Add(1)
+		return Model{}, false, errors.New("redis down")
+	}, CacheOptions{})
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.Get("ns.m"); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	if calls.Load() != 2 || c.Len() != 0 {
+		t.Errorf("loads = %d, len = %d; errors must not be cached", calls.Load(), c.Len())
+	}
+}
+
+func TestCachedLookupSingleflight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := NewCachedLookup(func(key string) (Model, bool, error) {
+		calls.Add(1)
+		<-release
+		return Model{Name: "gpt-4o"}, true, nil
+	}, CacheOptions{})
+
+	const n = 20
+	var wg sync.WaitGroup
+	results := make(chan string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m, _, _ := c.Get("ns.gpt-4o")
+			results <- m.Name
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loads = %d, want 1", n)
+	}
+	for name := range results {
+		if name != "gpt-4o" {
+			t.Errorf("result = %q", name)
+		}
+	}
+}
+
+func TestCachedLookupLoaderPanic(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := NewCachedLookup(func(key string) (Model, bool, error) {
+		if calls.Add(1) == 1 {
+			<-release
+			panic("boom")
+		}
+		return Model{Name: "gpt-4o"}, true, nil
+	}, CacheOptions{})
+
+	loaderPanicked := make(chan any, 1)
+	go func() {
+		defer func() { loaderPanicked <- recover() }()
+		c.Get("ns.gpt-4o")
+	}()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := c.Get("ns.gpt-4o")
+			errs <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("waiters blocked after the loader panicked")
+	}
+	if r := <-loaderPanicked; r != "boom" {
+		t.Fatalf("loading caller recovered %v, want the loader's panic", r)
+	}
+	close(errs)
+	for err := range errs {
+		if !errors.Is(err, ErrLoaderPanicked) {
+			t.Errorf("waiter err = %v, want ErrLoaderPanicked", err)
+		}
+	}
+
+	// The failed flight is gone; the next lookup loads again.
+	if m, found, err := c.Get("ns.gpt-4o"); err != nil || !found || m.Name != "gpt-4o" {
+		t.Fatalf("Get after panic = %+v, %v, %v", m, found, err)
+	}
+}
+
+func TestCachedLookupInvalidate(t *testing.T) {
+	var calls atomic.Int32
+	models := map[string]Model{"ns.a": {Name: "a"}}
+	c := NewCachedLookup(countingLoader(models, &calls), CacheOptions{})
+
+	c.Get("ns.a")
+	c.Get("ns.b")
+	// Catalog swap: b now exists, a changed.
+	models["ns.a"] = Model{Name: "a2"}
+	models["ns.b"] = Model{Name: "b"}
+	if _, found, _ := c.Get("ns.b"); found {
+		t.Fatal("stale negative entry should still be served before invalidation")
+	}
+
+	c.Invalidate("ns.b")
+	if m, found, _ := c.Get("ns.b"); !found || m.Name != "b" {
+		t.Errorf("after Invalidate: %+v, %v", m, found)
+	}
+	c.InvalidateAll()
+	if m, _, _ := c.Get("ns.a"); m.Name != "a2" {
+		t.Errorf("after InvalidateAll: %+v", m)
+	}
+	if n := calls.Load(); n != 4 {
+		t.Errorf("loads = %d, want 4", n)
+	}
+}
+
+func TestCachedLookupLRU(t *testing.T) {
+	var calls atomic.Int32
+	c := NewCachedLookup(countingLoader(nil, &calls), CacheOptions{Size: 2})
+	c.Get("ns.a")
+	c.Get("ns.b")
+	c.Get("ns.a") // a is now most recent
+	c.Get("ns.c") // evicts b
+	if c.Len() != 2 {
+		t.Fatalf("len = %d, want 2", c.Len())
+	}
+	c.Get("ns.a")
+	c.Get("ns.b")
+	if n := calls.Load(); n != 4 {
+		t.Errorf("loads = %d, want 4 (a kept, b evicted)", n)
+	}
+}
@@ -0,0 +1,43 @@
+package modelcap
+
+import (
+	"strings"
+	"time"
+)
+
+// ListedModel is the public projection of a model for /v1/models responses.
+type ListedModel struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	OwnedBy string `json:"owned_by"`
+}
+
+// Listed projects m, stored under bindingKey key, for /v1/models.
+func (m Model) Listed(key string) ListedModel {
+	ns, _, _ := strings.Cut(key, ".")
+	return ListedModel{ID: key, Object: "model", OwnedBy: ns}
+}
+
+// Retired reports whether m is past its retirement time at now.
+func (m Model) Retired(now time.Time) bool {
+	return m.RetireAt > 0 && now.Unix() >= m.RetireAt
+}
+
+// AdvertisedModels returns the models a gateway should list: entries of s that are
+// neither tombstones nor retired at now and whose bindingKey passes eligible (typically "has at least one
+// routable candidate"; a callback keeps modelcap free of a routing import), sorted by key.
+// A nil eligible accepts every key.
+func AdvertisedModels(s Set, eligible func(bindingKey string) bool, now time.Time) []ListedModel {
+	out := make([]ListedModel, 0, s.Len())
+	for _, key := range s.Keys() {
+		m := s.models[key]
+		if m.Deleted() || m.Retired(now) {
+			continue
+		}
+		if eligible != nil && !eligible(key) {
+			continue
+		}
+		out = append(out, m.Listed(key))
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package modelcap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdvertisedModels(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := NewSet()
+	entries := map[string]Model{
+		"openai.gpt-4o":      {Name: "gpt-4o"},
+		"openai.gpt-3.5":     {Name: "gpt-3.5", RetireAt: now.Unix()},
+		"openai.gpt-5":       {Name: "gpt-5", RetireAt: now.Unix() + 3600},
+		"anthropic.claude":   {Name: "claude"},
+		"internal.embedding": {Name: "embedding", Kind: "embedding"},
+	}
+	for key, m := range entries {
+		if err := s.Put(key, m); err != nil {
+			t.Fatalf("Put(%q): %v", key, err)
+		}
+	}
+	eligible := func(key string) bool { return key != "internal.embedding" }
+
+	got := AdvertisedModels(s, eligible, now)
+	want := []string{"anthropic.claude", "openai.gpt-4o", "openai.gpt-5"}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want ids %v", got, want)
+	}
+	for i, m := range got {
+		if m.ID != want[i] || m.Object != "model" {
+			t.Errorf("entry %d = %+v, want id %q", i, m, want[i])
+		}
+	}
+	if got[0].OwnedBy != "anthropic" {
+		t.Errorf("owned_by = %q, want anthropic", got[0].OwnedBy)
+	}
+
+	if all := AdvertisedModels(s, nil, now); len(all) != 4 {
+		t.Errorf("nil eligible: got %d entries, want 4", len(all))
+	}
+}
+
+func TestSetPutRejectsBadKey(t *testing.T) {
+	var s Set
+	if err := s.Put("gpt-4o", Model{Name: "gpt-4o"}); err == nil {
+		t.Error("expected error for key without namespace")
+	}
+	if err := s.Put("ns.m", Model{}); err == nil {
+		t.Error("expected validation error for model without name")
+	}
+	if err := s.Put("ns.m", Model{Name: "m"}); err != nil || s.Len() != 1 {
+		t.Errorf("Put on zero Set: err=%v len=%d", err, s.Len())
+	}
+}
@@ -43,6 +43,11 @@ type Model struct {
 	SupportsFim        bool    `json:"supports_fim,omitempty"`
 	SupportsStream     bool    `json:"supports_stream,omitempty"`
 	MaxOutputTokens    int     `json:"max_output_tokens,omitempty"`
+	RetireAt           int64   `json:"retire_at,omitempty"`  // unix seconds; 0 = not scheduled
+	DeletedAt          int64   `json:"deleted_at,omitempty"` // unix seconds; set on tombstones (see Set.MarkDeleted)
+
+	// ProviderOverrides patches capabilities per provider id for deployments that differ.
+	ProviderOverrides map[string]ModelPatch `json:"provider_overrides,omitempty"`
 }
 
 func (m Model) Normalized() Model {
@@ -62,7 +67,13 @@ func (m Model) Validate() error {
 	if m.MaxOutputTokens < 0 {
 		return errors.New("max_output_tokens must be >= 0")
 	}
-	return nil
+	if m.RetireAt < 0 {
+		return errors.New("retire_at must be >= 0")
+	}
+	if m.DeletedAt < 0 {
+		return errors.New("deleted_at must be >= 0")
+	}
+	return validateOverrides(m.ProviderOverrides)
 }
 
 // Meta is stored in Redis meta:models_meta (hash).
@@ -75,6 +86,25 @@ type Meta struct {
 	UpstreamRef string `json:"upstream_ref,omitempty"`
 }
 
+func (m Meta) Validate() error {
+	if strings.TrimSpace(m.Version) == "" {
+		return errors.New("version required")
+	}
+	if strings.TrimSpace(m.UpdatedAt) == "" {
+		return errors.New("updated_at required")
+	}
+	if strings.TrimSpace(m.Source) == "" {
+		return errors.New("source required")
+	}
+	if len(m.Checksum) != sha256.Size*2 {
+		return errors.New("checksum must be a sha256 hex digest")
+	}
+	if _, err := hex.DecodeString(m.Checksum); err != nil {
+		return errors.New("checksum must be a sha256 hex digest")
+	}
+	return nil
+}
+
 func ChecksumFromPayloads(payloads map[string]string) string {
 	keys := make([]string, 0, len(payloads))
 	for k := range payloads {
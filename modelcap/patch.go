@@ -0,0 +1,87 @@
+package modelcap
+
+import (
+	"errors"
+	"strings"
+)
+
+// ModelPatch is a partial Model; nil fields leave the base value unchanged.
+type ModelPatch struct {
+	Kind               *string  `json:"kind,omitempty"`
+	ContextWindow      *int     `json:"context_window,omitempty"`
+	CostPerToken       *float64 `json:"cost_per_token,omitempty"`
+	SupportsVision     *bool    `json:"supports_vision,omitempty"`
+	SupportsFunction   *bool    `json:"supports_functions,omitempty"`
+	SupportsToolChoice *bool    `json:"supports_tool_choice,omitempty"`
+	SupportsFim        *bool    `json:"supports_fim,omitempty"`
+	SupportsStream     *bool    `json:"supports_stream,omitempty"`
+	MaxOutputTokens    *int     `json:"max_output_tokens,omitempty"`
+}
+
+func (p ModelPatch) Validate() error {
+	if p.ContextWindow != nil && *p.ContextWindow < 0 {
+		return errors.New("context_window must be >= 0")
+	}
+	if p.MaxOutputTokens != nil && *p.MaxOutputTokens < 0 {
+		return errors.New("max_output_tokens must be >= 0")
+	}
+	if p.CostPerToken != nil && *p.CostPerToken < 0 {
+		return errors.New("cost_per_token must be >= 0")
+	}
+	return nil
+}
+
+// Apply returns m with the non-nil fields of p set.
+func (p ModelPatch) Apply(m Model) Model {
+	if p.Kind != nil {
+		m.Kind = *p.Kind
+	}
+	if p.ContextWindow != nil {
+		m.ContextWindow = *p.ContextWindow
+	}
+	if p.CostPerToken != nil {
+		m.CostPerToken = *p.CostPerToken
+	}
+	if p.SupportsVision != nil {
+		m.SupportsVision = *p.SupportsVision
+	}
+	if p.SupportsFunction != nil {
+		m.SupportsFunction = *p.SupportsFunction
+	}
+	if p.SupportsToolChoice != nil {
+		m.SupportsToolChoice = *p.SupportsToolChoice
+	}
+	if p.SupportsFim != nil {
+		m.SupportsFim = *p.SupportsFim
+	}
+	if p.SupportsStream != nil {
+		m.SupportsStream = *p.SupportsStream
+	}
+	if p.MaxOutputTokens != nil {
+		m.MaxOutputTokens = *p.MaxOutputTokens
+	}
+	return m
+}
+
+// ForProvider returns the model as served by providerID, with its override applied.
+// The returned model carries no overrides of its own.
+func (m Model) ForProvider(providerID string) Model {
+	patch, ok := m.ProviderOverrides[strings.TrimSpace(providerID)]
+	m.ProviderOverrides = nil
+	if !ok {
+		return m
+	}
+	return patch.Apply(m)
+}
+
+func validateOverrides(overrides map[string]ModelPatch) error {
+	for id, patch := range overrides {
+		if strings.TrimSpace(id) == "" {
+			return errors.New("provider_overrides key required")
+		}
+		if err := patch.Validate(); err != nil {
+			return errors.New("provider_overrides[" + id + "]: " + err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package modelcap
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestModelForProvider(t *testing.T) {
+	noVision := false
+	window := 32000
+	m := Model{
+		Name:           "gpt-4o",
+		ContextWindow:  128000,
+		SupportsVision: true,
+		ProviderOverrides: map[string]ModelPatch{
+			"azure-east": {SupportsVision: &noVision, ContextWindow: &window},
+		},
+	}
+
+	got := m.ForProvider("azure-east")
+	if got.SupportsVision || got.ContextWindow != 32000 || got.Name != "gpt-4o" {
+		t.Errorf("override not applied: %+v", got)
+	}
+	if got.ProviderOverrides != nil {
+		t.Errorf("expected overrides stripped, got %v", got.ProviderOverrides)
+	}
+	if base := m.ForProvider("openai"); !base.SupportsVision || base.ContextWindow != 128000 {
+		t.Errorf("unexpected base model: %+v", base)
+	}
+	if !m.SupportsVision {
+		t.Error("ForProvider mutated the receiver")
+	}
+}
+
+func TestModelValidateOverrides(t *testing.T) {
+	neg := -1
+	tests := []struct {
+		name      string
+		overrides map[string]ModelPatch
+		wantErr   bool
+	}{
+		{"none", nil, false},
+		{"valid", map[string]ModelPatch{"p1": {ContextWindow: new(int)}}, false},
+		{"empty key", map[string]ModelPatch{" ": {}}, true},
+		{"invalid patch", map[string]ModelPatch{"p1": {MaxOutputTokens: &neg}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Model{Name: "m", ProviderOverrides: tt.overrides}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModelOverridesMarshalSorted(t *testing.T) {
+	yes := true
+	m := Model{Name: "m", ProviderOverrides: map[string]ModelPatch{
+		"zeta":  {SupportsStream: &yes},
+		"alpha": {SupportsFim: &yes},
+	}}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"m","provider_overrides":{"alpha":{"supports_fim":true},"zeta":{"supports_stream":true}}}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
@@ -0,0 +1,56 @@
+package modelcap
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Set is an in-memory catalog of models keyed by bindingKey (namespace.public_model),
+// mirroring the meta:models hash. The zero value is an empty set ready for Put.
+type Set struct {
+	models     map[string]Model
+	validators *ValidatorSet
+}
+
+func NewSet() Set {
+	return Set{models: make(map[string]Model)}
+}
+
+// NewSetWithValidators returns an empty set whose Put also enforces the rules in vs.
+func NewSetWithValidators(vs *ValidatorSet) Set {
+	return Set{models: make(map[string]Model), validators: vs}
+}
+
+// Put validates m (see ValidateWith) and stores it under key, replacing any previous entry.
+func (s *Set) Put(key string, m Model) error {
+	key = strings.TrimSpace(key)
+	if ns, model, ok := strings.Cut(key, "."); !ok || ns == "" || model == "" {
+		return errors.New("key must be a bindingKey (namespace.public_model)")
+	}
+	if err := ValidateWith(m, s.validators); err != nil {
+		return err
+	}
+	if s.models == nil {
+		s.models = make(map[string]Model)
+	}
+	s.models[key] = m
+	return nil
+}
+
+func (s Set) Get(key string) (Model, bool) {
+	m, ok := s.models[key]
+	return m, ok
+}
+
+func (s Set) Len() int { return len(s.models) }
+
+// Keys returns the bindingKeys in sorted order.
+func (s Set) Keys() []string {
+	keys := make([]string, 0, len(s.models))
+	for k := range s.models {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
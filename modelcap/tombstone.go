@@ -0,0 +1,140 @@
+package modelcap
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ez-api/foundation/jsoncodec"
+)
+
+// Deleted reports whether m is a tombstone left by Set.MarkDeleted.
+func (m Model) Deleted() bool { return m.DeletedAt > 0 }
+
+// MarkDeleted replaces the entry for key with a tombstone carrying only its name and
+// DeletedAt, so readers can tell an intentional removal from a missing entry.
+// It reports false if key is absent or already a tombstone.
+func (s *Set) MarkDeleted(key string, now time.Time) bool {
+	m, ok := s.models[key]
+	if !ok || m.Deleted() {
+		return false
+	}
+	s.models[key] = Model{Name: m.Name, DeletedAt: now.Unix()}
+	return true
+}
+
+// Compact drops tombstones deleted more than olderThan before now and returns how many
+// were removed.
+func (s *Set) Compact(olderThan time.Duration, now time.Time) int {
+	cutoff := now.Add(-olderThan).Unix()
+	n := 0
+	for key, m := range s.models {
+		if m.Deleted() && m.DeletedAt < cutoff {
+			delete(s.models, key)
+			n++
+		}
+	}
+	return n
+}
+
+// Payloads returns the meta:models hash view of s (bindingKey -> compact model JSON
+// with sorted map keys), tombstones included, as hashed by ChecksumFromPayloads.
+func (s Set) Payloads() (map[string]string, error) {
+	out := make(map[string]string, len(s.models))
+	for key, m := range s.models {
+		b, err := jsoncodec.MarshalCanonicalCompact(m)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = string(b)
+	}
+	return out, nil
+}
+
+// Checksum is ChecksumFromPayloads over s.Payloads().
+func (s Set) Checksum() (string, error) {
+	payloads, err := s.Payloads()
+	if err != nil {
+		return "", err
+	}
+	return ChecksumFromPayloads(payloads), nil
+}
+
+// SetDiff lists the bindingKeys that changed between two sets. Overrides maps each
+// Changed key whose ProviderOverrides differ to the sorted provider ids whose override
+// was added, changed or removed.
+type SetDiff struct {
+	Added     []string            `json:"added,omitempty"`
+	Changed   []string            `json:"changed,omitempty"`
+	Removed   []string            `json:"removed,omitempty"`
+	Overrides map[string][]string `json:"overrides,omitempty"`
+}
+
+// Empty reports whether the diff has no changes.
+func (d SetDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// DiffSets compares old and next. A live entry that became a tombstone in next is
+// reported as Removed, as is a live entry missing from next; tombstones never count
+// as Added or Changed. Keys are sorted.
+func DiffSets(old, next Set) SetDiff {
+	var d SetDiff
+	for _, key := range next.Keys() {
+		m := next.models[key]
+		prev, had := old.models[key]
+		live := had && !prev.Deleted()
+		switch {
+		case m.Deleted():
+			if live {
+				d.Removed = append(d.Removed, key)
+			}
+		case !live:
+			d.Added = append(d.Added, key)
+		case !modelsEqual(prev, m):
+			d.Changed = append(d.Changed, key)
+			if providers := overrideChanges(prev.ProviderOverrides, m.ProviderOverrides); len(providers) > 0 {
+				if d.Overrides == nil {
+					d.Overrides = make(map[string][]string)
+				}
+				d.Overrides[key] = providers
+			}
+		}
+	}
+	for _, key := range old.Keys() {
+		if _, ok := next.models[key]; !ok && !old.models[key].Deleted() {
+			d.Removed = append(d.Removed, key)
+		}
+	}
+	sort.Strings(d.Removed)
+	return d
+}
+
+func modelsEqual(a, b Model) bool {
+	ab, errA := jsoncodec.MarshalCanonicalCompact(a)
+	bb, errB := jsoncodec.MarshalCanonicalCompact(b)
+	return errA == nil && errB == nil && string(ab) == string(bb)
+}
+
+// overrideChanges returns the sorted provider ids whose override differs between old
+// and next, including ones present on only one side.
+func overrideChanges(old, next map[string]ModelPatch) []string {
+	var out []string
+	for id, p := range next {
+		if prev, ok := old[id]; !ok || !patchesEqual(prev, p) {
+			out = append(out, id)
+		}
+	}
+	for id := range old {
+		if _, ok := next[id]; !ok {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func patchesEqual(a, b ModelPatch) bool {
+	ab, errA := jsoncodec.MarshalCanonicalCompact(a)
+	bb, errB := jsoncodec.MarshalCanonicalCompact(b)
+	return errA == nil && errB == nil && string(ab) == string(bb)
+}
@@ -0,0 +1,152 @@
+package modelcap
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTombstoneLifecycle(t *testing.T) {
+	t0 := time.Unix(1_700_000_000, 0)
+
+	// CP publishes two models; a DP caches that set.
+	cp := NewSet()
+	for key, m := range map[string]Model{
+		"openai.gpt-4o": {Name: "gpt-4o", ContextWindow: 128000},
+		"openai.gpt-3":  {Name: "gpt-3", ContextWindow: 4096},
+	} {
+		if err := cp.Put(key, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dpCached := cloneSet(cp)
+	before, err := cp.Checksum()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// CP deletes one model: a tombstone stays and changes the checksum.
+	if !cp.MarkDeleted("openai.gpt-3", t0) {
+		t.Fatal("MarkDeleted returned false")
+	}
+	if cp.MarkDeleted("openai.gpt-3", t0) || cp.MarkDeleted("openai.missing", t0) {
+		t.Error("MarkDeleted should be false for tombstones and missing keys")
+	}
+	tomb, ok := cp.Get("openai.gpt-3")
+	if !ok || !tomb.Deleted() || tomb.Name != "gpt-3" || tomb.ContextWindow != 0 {
+		t.Fatalf("unexpected tombstone: %+v", tomb)
+	}
+	after, _ := cp.Checksum()
+	if after == before {
+		t.Error("checksum must cover tombstones")
+	}
+
+	// DP observes the removal explicitly.
+	diff := DiffSets(dpCached, cp)
+	if len(diff.Added) != 0 || len(diff.Changed) != 0 || len(diff.Removed) != 1 || diff.Removed[0] != "openai.gpt-3" {
+		t.Fatalf("diff = %+v", diff)
+	}
+	if got := AdvertisedModels(cp, nil, t0); len(got) != 1 || got[0].ID != "openai.gpt-4o" {
+		t.Errorf("tombstone advertised: %+v", got)
+	}
+
+	// Compaction keeps tombstones inside the retention window and drops older ones.
+	if n := cp.Compact(time.Hour, t0.Add(30*time.Minute)); n != 0 {
+		t.Errorf("compacted %d tombstones inside retention window", n)
+	}
+	if n := cp.Compact(time.Hour, t0.Add(2*time.Hour)); n != 1 {
+		t.Errorf("compacted %d, want 1", n)
+	}
+	if _, ok := cp.Get("openai.gpt-3"); ok || cp.Len() != 1 {
+		t.Error("tombstone should be gone after compaction")
+	}
+
+	// A DP that already applied the tombstone sees no further change.
+	if diff := DiffSets(dpCached, cp); len(diff.Removed) != 1 {
+		t.Errorf("missing entry should still read as removed: %+v", diff)
+	}
+}
+
+func TestDiffSetsAddedChanged(t *testing.T) {
+	old := NewSet()
+	_ = old.Put("ns.a", Model{Name: "a"})
+	next := NewSet()
+	_ = next.Put("ns.a", Model{Name: "a", SupportsStream: true})
+	_ = next.Put("ns.b", Model{Name: "b"})
+
+	d := DiffSets(old, next)
+	if len(d.Added) != 1 || d.Added[0] != "ns.b" || len(d.Changed) != 1 || d.Changed[0] != "ns.a" || len(d.Removed) != 0 {
+		t.Errorf("diff = %+v", d)
+	}
+	if !DiffSets(next, next).Empty() {
+		t.Error("diff of identical sets should be empty")
+	}
+}
+
+func TestDiffSetsOverrides(t *testing.T) {
+	window := func(n int) *int { return &n }
+	vision := true
+	old := NewSet()
+	_ = old.Put("ns.a", Model{Name: "a", ProviderOverrides: map[string]ModelPatch{
+		"azure":  {ContextWindow: window(64000)},
+		"openai": {SupportsVision: &vision},
+		"vertex": {MaxOutputTokens: window(4096)},
+	}})
+	_ = old.Put("ns.b", Model{Name: "b"})
+	next := NewSet()
+	_ = next.Put("ns.a", Model{Name: "a", ProviderOverrides: map[string]ModelPatch{
+		"azure":      {ContextWindow: window(32000)}, // changed
+		"openai":     {SupportsVision: &vision},      // unchanged
+		"openrouter": {ContextWindow: window(8000)},  // added; vertex removed
+	}})
+	_ = next.Put("ns.b", Model{Name: "b", SupportsStream: true})
+
+	d := DiffSets(old, next)
+	if !reflect.DeepEqual(d.Changed, []string{"ns.a", "ns.b"}) {
+		t.Fatalf("changed = %v", d.Changed)
+	}
+	want := map[string][]string{"ns.a": {"azure", "openrouter", "vertex"}}
+	if !reflect.DeepEqual(d.Overrides, want) {
+		t.Errorf("overrides = %v, want %v", d.Overrides, want)
+	}
+}
+
+func cloneSet(s Set) Set {
+	out := NewSet()
+	for key, m := range s.models {
+		out.models[key] = m
+	}
+	return out
+}
+
+func TestChecksumStableWithOverrides(t *testing.T) {
+	window := func(n int) *int { return &n }
+	vision := true
+	m := Model{Name: "gpt-4o", ContextWindow: 128000, ProviderOverrides: map[string]ModelPatch{
+		"azure-east":  {ContextWindow: window(64000)},
+		"azure-west":  {ContextWindow: window(32000)},
+		"openai":      {SupportsVision: &vision},
+		"openrouter":  {MaxOutputTokens: window(4096)},
+		"vertex-asia": {ContextWindow: window(16000)},
+	}}
+
+	var first string
+	for i := 0; i < 50; i++ {
+		s := NewSet()
+		if err := s.Put("openai.gpt-4o", m); err != nil {
+			t.Fatal(err)
+		}
+		sum, err := s.Checksum()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			first = sum
+		} else if sum != first {
+			t.Fatalf("run %d: checksum %s, want %s", i, sum, first)
+		}
+		if d := DiffSets(s, cloneSet(s)); !d.Empty() {
+			t.Fatalf("run %d: spurious diff %+v", i, d)
+		}
+	}
+}
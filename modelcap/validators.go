@@ -0,0 +1,60 @@
+package modelcap
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ValidatorSet holds named policy rules that run on top of Model.Validate, e.g.
+// "chat models must have pricing". Rules run in registration order. A nil
+// *ValidatorSet has no rules.
+type ValidatorSet struct {
+	mu    sync.RWMutex
+	rules []namedValidator
+}
+
+type namedValidator struct {
+	name string
+	fn   func(Model) error
+}
+
+func NewValidatorSet() *ValidatorSet {
+	return &ValidatorSet{}
+}
+
+// Register adds a rule under name. Names must be non-empty and unique within the set.
+func (vs *ValidatorSet) Register(name string, fn func(Model) error) error {
+	if name == "" {
+		return errors.New("validator name required")
+	}
+	if fn == nil {
+		return fmt.Errorf("validator %q: nil function", name)
+	}
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	for _, r := range vs.rules {
+		if r.name == name {
+			return fmt.Errorf("validator %q already registered", name)
+		}
+	}
+	vs.rules = append(vs.rules, namedValidator{name: name, fn: fn})
+	return nil
+}
+
+// ValidateWith runs m.Validate and every rule in vs against the normalized model.
+// All failures are joined; rule errors are prefixed with the rule name.
+func ValidateWith(m Model, vs *ValidatorSet) error {
+	errs := []error{m.Validate()}
+	if vs != nil {
+		m = m.Normalized()
+		vs.mu.RLock()
+		defer vs.mu.RUnlock()
+		for _, r := range vs.rules {
+			if err := r.fn(m); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", r.name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,77 @@
+package modelcap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func policyRules(t *testing.T) *ValidatorSet {
+	t.Helper()
+	vs := NewValidatorSet()
+	if err := vs.Register("chat-pricing", func(m Model) error {
+		if m.Kind == string(KindChat) && m.CostPerToken == 0 {
+			return errors.New("chat models must have pricing")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := vs.Register("context-window", func(m Model) error {
+		switch m.ContextWindow {
+		case 8192, 32768, 128000:
+			return nil
+		}
+		return errors.New("context window not in allowed list")
+	}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	return vs
+}
+
+func TestValidateWith(t *testing.T) {
+	vs := policyRules(t)
+
+	err := ValidateWith(Model{Name: "gpt-4o", Kind: "chat", ContextWindow: 1000}, vs)
+	if err == nil {
+		t.Fatal("expected policy errors")
+	}
+	for _, want := range []string{"chat-pricing: chat models must have pricing", "context-window: context window not in allowed list"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+
+	if err := ValidateWith(Model{Name: "gpt-4o", Kind: "chat", ContextWindow: 128000, CostPerToken: 0.01}, vs); err != nil {
+		t.Errorf("valid model: %v", err)
+	}
+	if err := ValidateWith(Model{ContextWindow: 8192, CostPerToken: 1}, vs); err == nil || !strings.Contains(err.Error(), "name required") {
+		t.Errorf("structural error not reported: %v", err)
+	}
+	if err := ValidateWith(Model{Name: "x"}, nil); err != nil {
+		t.Errorf("nil set: %v", err)
+	}
+}
+
+func TestValidatorSetRegister(t *testing.T) {
+	vs := policyRules(t)
+	if err := vs.Register("chat-pricing", func(Model) error { return nil }); err == nil {
+		t.Error("duplicate name should fail")
+	}
+	if err := vs.Register("", func(Model) error { return nil }); err == nil {
+		t.Error("empty name should fail")
+	}
+	if err := vs.Register("nil", nil); err == nil {
+		t.Error("nil fn should fail")
+	}
+}
+
+func TestSetWithValidators(t *testing.T) {
+	s := NewSetWithValidators(policyRules(t))
+	if err := s.Put("acme.gpt-4o", Model{Name: "gpt-4o", Kind: "chat", ContextWindow: 128000}); err == nil || !strings.Contains(err.Error(), "chat-pricing") {
+		t.Errorf("Put err = %v, want chat-pricing failure", err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("rejected model stored")
+	}
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"slices"
+	"strings"
+)
+
+// Credential sources reported by CredentialFromEnv.
+const (
+	SourceEnv  = "env"  // the secret is the variable's value
+	SourceFile = "file" // the value is a path to a credentials file (Google ADC)
+)
+
+// ConventionalEnvVars returns the environment variables CredentialFromEnv consults for
+// providerType, in precedence order. Types without a convention (compatible,
+// antigravity) and unknown types have none.
+func ConventionalEnvVars(providerType string) []string {
+	return slices.Clone(registry[NormalizeType(providerType)].EnvVars)
+}
+
+// CredentialFromEnv resolves a provider credential from the first non-empty conventional
+// variable. For GOOGLE_APPLICATION_CREDENTIALS the returned secret is the file path and
+// source is SourceFile; otherwise source is SourceEnv.
+func CredentialFromEnv(providerType string, getenv func(string) string) (secret string, source string, ok bool) {
+	if getenv == nil {
+		return "", "", false
+	}
+	for _, name := range registry[NormalizeType(providerType)].EnvVars {
+		value := strings.TrimSpace(getenv(name))
+		if value == "" {
+			continue
+		}
+		if name == googleADCEnv {
+			return value, SourceFile, true
+		}
+		return value, SourceEnv, true
+	}
+	return "", "", false
+}
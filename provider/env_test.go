@@ -0,0 +1,57 @@
+package provider
+
+import "testing"
+
+func TestCredentialFromEnv(t *testing.T) {
+	tests := []struct {
+		name       string
+		typ        string
+		env        map[string]string
+		wantSecret string
+		wantSource string
+		wantOK     bool
+	}{
+		{"openai", "OpenAI", map[string]string{"OPENAI_API_KEY": " sk-1 "}, "sk-1", SourceEnv, true},
+		{"anthropic", "anthropic", map[string]string{"ANTHROPIC_API_KEY": "sk-ant"}, "sk-ant", SourceEnv, true},
+		{"claude code prefers oauth token", "claude-code", map[string]string{"ANTHROPIC_API_KEY": "sk-ant", "CLAUDE_CODE_OAUTH_TOKEN": "oauth"}, "oauth", SourceEnv, true},
+		{"codex falls back to openai", "codex", map[string]string{"OPENAI_API_KEY": "sk-1"}, "sk-1", SourceEnv, true},
+		{"gemini prefers GEMINI_API_KEY", "gemini", map[string]string{"GOOGLE_API_KEY": "g", "GEMINI_API_KEY": "gm"}, "gm", SourceEnv, true},
+		{"google prefers GOOGLE_API_KEY", "google", map[string]string{"GOOGLE_API_KEY": "g", "GEMINI_API_KEY": "gm"}, "g", SourceEnv, true},
+		{"vertex adc file", "vertex", map[string]string{googleADCEnv: "/etc/adc.json"}, "/etc/adc.json", SourceFile, true},
+		{"vertex express key before adc", "vertex-express", map[string]string{"GOOGLE_API_KEY": "g", googleADCEnv: "/etc/adc.json"}, "g", SourceEnv, true},
+		{"empty value skipped", "gemini-cli", map[string]string{"GEMINI_API_KEY": "  ", googleADCEnv: "/adc"}, "/adc", SourceFile, true},
+		{"nothing set", "openai", nil, "", "", false},
+		{"compatible has no convention", "compatible", map[string]string{"OPENAI_API_KEY": "sk-1"}, "", "", false},
+		{"unknown type", "mystery", map[string]string{"OPENAI_API_KEY": "sk-1"}, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, source, ok := CredentialFromEnv(tt.typ, func(k string) string { return tt.env[k] })
+			if secret != tt.wantSecret || source != tt.wantSource || ok != tt.wantOK {
+				t.Errorf("CredentialFromEnv(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.typ, secret, source, ok, tt.wantSecret, tt.wantSource, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestConventionalEnvVars(t *testing.T) {
+	for _, typ := range Types() {
+		vars := ConventionalEnvVars(typ)
+		if typ == TypeCompatible || typ == TypeAntigravity {
+			if vars != nil {
+				t.Errorf("%s: expected no conventional vars, got %v", typ, vars)
+			}
+			continue
+		}
+		if len(vars) == 0 {
+			t.Errorf("%s: expected conventional vars", typ)
+		}
+	}
+
+	vars := ConventionalEnvVars("openai")
+	vars[0] = "MUTATED"
+	if ConventionalEnvVars("openai")[0] != "OPENAI_API_KEY" {
+		t.Error("ConventionalEnvVars must return a copy")
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const vertexGlobalHost = "aiplatform.googleapis.com"
+
+// UpstreamHosts returns the lowercase hostnames (without port) an adapter may dial for
+// this provider, sorted and deduplicated, e.g. for an egress allowlist. A BaseURL
+// replaces the type's default API hosts; OAuth token hosts are always included. Vertex
+// without BaseURL uses the global endpoint plus "{location}-aiplatform.googleapis.com"
+// for a regional GoogleLocation. Compatible providers require BaseURL.
+func (s Snapshot) UpstreamHosts() ([]string, error) {
+	typ := NormalizeType(s.Type)
+	info, ok := registry[typ]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider type %q", s.Type)
+	}
+
+	var hosts []string
+	switch base := strings.TrimSpace(s.BaseURL); {
+	case base != "":
+		host, err := baseURLHost(base)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, host)
+	case typ == TypeVertex:
+		hosts = append(hosts, vertexGlobalHost)
+		location := strings.ToLower(DefaultGoogleLocation(typ, s.GoogleLocation))
+		if location != "global" {
+			if !validLocation(location) {
+				return nil, fmt.Errorf("invalid google_location %q", s.GoogleLocation)
+			}
+			hosts = append(hosts, location+"-"+vertexGlobalHost)
+		}
+	case typ == TypeCompatible:
+		return nil, fmt.Errorf("provider type %q requires base_url", typ)
+	default:
+		hosts = append(hosts, info.APIHosts...)
+	}
+	hosts = append(hosts, info.TokenHosts...)
+
+	sort.Strings(hosts)
+	out := hosts[:0]
+	for i, h := range hosts {
+		if i == 0 || h != hosts[i-1] {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+func baseURLHost(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid base_url %q: %w", raw, err)
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return "", fmt.Errorf("invalid base_url %q: missing host", raw)
+	}
+	return host, nil
+}
+
+func validLocation(location string) bool {
+	for i := 0; i < len(location); i++ {
+		c := location[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return location != ""
+}
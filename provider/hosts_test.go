@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotUpstreamHosts(t *testing.T) {
+	tests := []struct {
+		name string
+		snap Snapshot
+		want []string
+	}{
+		{"openai", Snapshot{Type: TypeOpenAI}, []string{"api.openai.com"}},
+		{"anthropic", Snapshot{Type: " Anthropic "}, []string{"api.anthropic.com"}},
+		{"gemini", Snapshot{Type: TypeGemini}, []string{"generativelanguage.googleapis.com"}},
+		{"vertex default location", Snapshot{Type: TypeVertex}, []string{"aiplatform.googleapis.com", "oauth2.googleapis.com"}},
+		{"vertex regional", Snapshot{Type: TypeVertex, GoogleLocation: "US-Central1"}, []string{
+			"aiplatform.googleapis.com", "oauth2.googleapis.com", "us-central1-aiplatform.googleapis.com",
+		}},
+		{"vertex express", Snapshot{Type: TypeVertexExpress, GoogleLocation: "europe-west4"}, []string{"aiplatform.googleapis.com"}},
+		{"claude code", Snapshot{Type: TypeClaudeCode}, []string{"api.anthropic.com", "console.anthropic.com"}},
+		{"codex", Snapshot{Type: TypeCodex}, []string{"auth.openai.com", "chatgpt.com"}},
+		{"gemini cli", Snapshot{Type: TypeGeminiCLI}, []string{"cloudcode-pa.googleapis.com", "oauth2.googleapis.com"}},
+		{"compatible", Snapshot{Type: TypeCompatible, BaseURL: "https://LLM.internal:8443/v1"}, []string{"llm.internal"}},
+		{"base url overrides default", Snapshot{Type: TypeOpenAI, BaseURL: "https://gateway.example.com/openai"}, []string{"gateway.example.com"}},
+		{"base url overrides vertex endpoints", Snapshot{Type: TypeVertex, GoogleLocation: "us-east5", BaseURL: "https://vertex-proxy.example.com"}, []string{
+			"oauth2.googleapis.com", "vertex-proxy.example.com",
+		}},
+		{"base url keeps token host", Snapshot{Type: TypeGeminiCLI, BaseURL: "https://oauth2.googleapis.com/"}, []string{"oauth2.googleapis.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.snap.UpstreamHosts()
+			if err != nil {
+				t.Fatalf("UpstreamHosts: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UpstreamHosts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotUpstreamHostsErrors(t *testing.T) {
+	for _, snap := range []Snapshot{
+		{Type: "mystery"},
+		{Type: TypeCompatible},
+		{Type: TypeOpenAI, BaseURL: "api.openai.com/v1"},
+		{Type: TypeOpenAI, BaseURL: "http://[::1"},
+		{Type: TypeVertex, GoogleLocation: "us central1"},
+	} {
+		if hosts, err := snap.UpstreamHosts(); err == nil {
+			t.Errorf("%+v: expected error, got %v", snap, hosts)
+		}
+	}
+}
+
+func TestUpstreamHostsCoversAllTypes(t *testing.T) {
+	for _, typ := range Types() {
+		snap := Snapshot{Type: typ}
+		if typ == TypeCompatible {
+			snap.BaseURL = "https://example.com"
+		}
+		if hosts, err := snap.UpstreamHosts(); err != nil || len(hosts) == 0 {
+			t.Errorf("%s: hosts = %v, err = %v", typ, hosts, err)
+		}
+	}
+}
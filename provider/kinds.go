@@ -0,0 +1,17 @@
+package provider
+
+import (
+	"slices"
+
+	"github.com/ez-api/foundation/modelcap"
+)
+
+// SupportedKinds returns the model kinds providerType can serve, or nil for unknown types.
+func SupportedKinds(providerType string) []modelcap.Kind {
+	return slices.Clone(registry[NormalizeType(providerType)].Kinds)
+}
+
+// SupportsKind reports whether providerType can serve models of kind k.
+func SupportsKind(providerType string, k modelcap.Kind) bool {
+	return slices.Contains(registry[NormalizeType(providerType)].Kinds, k)
+}
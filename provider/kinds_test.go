@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ez-api/foundation/modelcap"
+)
+
+func TestSupportsKind(t *testing.T) {
+	const (
+		c = 1 << iota // chat
+		e             // embedding
+		r             // rerank
+		o             // other
+	)
+	want := map[string]int{
+		TypeOpenAI:        c | e,
+		TypeCompatible:    c | e | r | o,
+		TypeAnthropic:     c,
+		TypeClaude:        c,
+		TypeClaudeCode:    c,
+		TypeCodex:         c,
+		TypeGeminiCLI:     c,
+		TypeAntigravity:   c,
+		TypeGemini:        c | e,
+		TypeGoogle:        c | e,
+		TypeAIStudio:      c | e,
+		TypeVertex:        c | e,
+		TypeVertexExpress: c | e,
+	}
+	kinds := map[modelcap.Kind]int{
+		modelcap.KindChat:      c,
+		modelcap.KindEmbedding: e,
+		modelcap.KindRerank:    r,
+		modelcap.KindOther:     o,
+	}
+
+	if len(want) != len(Types()) {
+		t.Fatalf("expectations cover %d types, registry has %d", len(want), len(Types()))
+	}
+	for _, typ := range Types() {
+		mask, ok := want[typ]
+		if !ok {
+			t.Errorf("no expectation for provider type %q", typ)
+			continue
+		}
+		for kind, bit := range kinds {
+			if got := SupportsKind(typ, kind); got != (mask&bit != 0) {
+				t.Errorf("SupportsKind(%q, %q) = %v", typ, kind, got)
+			}
+		}
+		if len(SupportedKinds(typ)) == 0 {
+			t.Errorf("SupportedKinds(%q) is empty", typ)
+		}
+	}
+
+	if SupportsKind(" OpenAI ", modelcap.KindEmbedding) != true {
+		t.Error("expected type normalization")
+	}
+	if SupportedKinds("unknown") != nil || SupportsKind("unknown", modelcap.KindChat) {
+		t.Error("unknown types support nothing")
+	}
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/ez-api/foundation/modelcap"
+)
+
+// Provider families group types for display. FamilyGoogle matches IsGoogleFamily.
+const (
+	FamilyOpenAI    = "openai"
+	FamilyAnthropic = "anthropic"
+	FamilyGoogle    = "google"
+	FamilyCLI       = "cli" // subscription CLIs proxied through their OAuth clients
+)
+
+// TypeInfo describes a provider type. The listing fields are served to UI pickers; the
+// remaining fields back SupportedKinds, ConventionalEnvVars and Snapshot.UpstreamHosts and
+// are not part of the listing JSON. Adding a provider type means adding one entry here.
+type TypeInfo struct {
+	Type        string `json:"type"`
+	DisplayName string `json:"display_name"`
+	Family      string `json:"family"`
+	SortOrder   int    `json:"sort_order"`
+
+	// Kinds are the modelcap kinds the type has endpoints for.
+	Kinds []modelcap.Kind `json:"-"`
+	// EnvVars are the conventional credential variables, in precedence order.
+	EnvVars []string `json:"-"`
+	// APIHosts are the default API hosts, used when BaseURL is empty.
+	APIHosts []string `json:"-"`
+	// TokenHosts are the OAuth token endpoints, contacted whatever BaseURL says.
+	TokenHosts []string `json:"-"`
+}
+
+func (info TypeInfo) clone() TypeInfo {
+	info.Kinds = slices.Clone(info.Kinds)
+	info.EnvVars = slices.Clone(info.EnvVars)
+	info.APIHosts = slices.Clone(info.APIHosts)
+	info.TokenHosts = slices.Clone(info.TokenHosts)
+	return info
+}
+
+var (
+	kindsChatOnly      = []modelcap.Kind{modelcap.KindChat}
+	kindsChatEmbedding = []modelcap.Kind{modelcap.KindChat, modelcap.KindEmbedding}
+	kindsAll           = []modelcap.Kind{modelcap.KindChat, modelcap.KindEmbedding, modelcap.KindRerank, modelcap.KindOther}
+)
+
+const googleADCEnv = "GOOGLE_APPLICATION_CREDENTIALS"
+
+// registry holds every known provider type. Compatible providers are arbitrary
+// OpenAI-style gateways: every kind is allowed and BaseURL is required.
+var registry = map[string]TypeInfo{
+	TypeOpenAI: {
+		Type: TypeOpenAI, DisplayName: "OpenAI", Family: FamilyOpenAI, SortOrder: 10,
+		Kinds: kindsChatEmbedding, EnvVars: []string{"OPENAI_API_KEY"},
+		APIHosts: []string{"api.openai.com"},
+	},
+	TypeCompatible: {
+		Type: TypeCompatible, DisplayName: "OpenAI Compatible", Family: FamilyOpenAI, SortOrder: 20,
+		Kinds: kindsAll,
+	},
+	TypeAnthropic: {
+		Type: TypeAnthropic, DisplayName: "Anthropic", Family: FamilyAnthropic, SortOrder: 30,
+		Kinds: kindsChatOnly, EnvVars: []string{"ANTHROPIC_API_KEY"},
+		APIHosts: []string{"api.anthropic.com"},
+	},
+	TypeClaude: {
+		Type: TypeClaude, DisplayName: "Anthropic Claude", Family: FamilyAnthropic, SortOrder: 40,
+		Kinds: kindsChatOnly, EnvVars: []string{"ANTHROPIC_API_KEY"},
+		APIHosts: []string{"api.anthropic.com"},
+	},
+	TypeGemini: {
+		Type: TypeGemini, DisplayName: "Google Gemini", Family: FamilyGoogle, SortOrder: 50,
+		Kinds: kindsChatEmbedding, EnvVars: []string{"GEMINI_API_KEY", "GOOGLE_API_KEY"},
+		APIHosts: []string{"generativelanguage.googleapis.com"},
+	},
+	TypeGoogle: {
+		Type: TypeGoogle, DisplayName: "Google", Family: FamilyGoogle, SortOrder: 60,
+		Kinds: kindsChatEmbedding, EnvVars: []string{"GOOGLE_API_KEY", "GEMINI_API_KEY", googleADCEnv},
+		APIHosts: []string{"generativelanguage.googleapis.com"},
+	},
+	TypeAIStudio: {
+		Type: TypeAIStudio, DisplayName: "Google AI Studio", Family: FamilyGoogle, SortOrder: 70,
+		Kinds: kindsChatEmbedding, EnvVars: []string{"GEMINI_API_KEY", "GOOGLE_API_KEY"},
+		APIHosts: []string{"generativelanguage.googleapis.com"},
+	},
+	TypeVertex: {
+		// API hosts depend on GoogleLocation; see Snapshot.UpstreamHosts.
+		Type: TypeVertex, DisplayName: "Google Vertex AI", Family: FamilyGoogle, SortOrder: 80,
+		Kinds: kindsChatEmbedding, EnvVars: []string{googleADCEnv},
+		TokenHosts: []string{"oauth2.googleapis.com"},
+	},
+	TypeVertexExpress: {
+		Type: TypeVertexExpress, DisplayName: "Google Vertex AI Express", Family: FamilyGoogle, SortOrder: 90,
+		Kinds: kindsChatEmbedding, EnvVars: []string{"GOOGLE_API_KEY", googleADCEnv},
+		APIHosts: []string{"aiplatform.googleapis.com"},
+	},
+	TypeClaudeCode: {
+		Type: TypeClaudeCode, DisplayName: "Claude Code", Family: FamilyCLI, SortOrder: 100,
+		Kinds: kindsChatOnly, EnvVars: []string{"CLAUDE_CODE_OAUTH_TOKEN", "ANTHROPIC_API_KEY"},
+		APIHosts: []string{"api.anthropic.com"}, TokenHosts: []string{"console.anthropic.com"},
+	},
+	TypeCodex: {
+		Type: TypeCodex, DisplayName: "OpenAI Codex", Family: FamilyCLI, SortOrder: 110,
+		Kinds: kindsChatOnly, EnvVars: []string{"CODEX_API_KEY", "OPENAI_API_KEY"},
+		APIHosts: []string{"chatgpt.com"}, TokenHosts: []string{"auth.openai.com"},
+	},
+	TypeGeminiCLI: {
+		Type: TypeGeminiCLI, DisplayName: "Gemini CLI", Family: FamilyCLI, SortOrder: 120,
+		Kinds: kindsChatOnly, EnvVars: []string{"GEMINI_API_KEY", "GOOGLE_API_KEY", googleADCEnv},
+		APIHosts: []string{"cloudcode-pa.googleapis.com"}, TokenHosts: []string{"oauth2.googleapis.com"},
+	},
+	TypeAntigravity: {
+		Type: TypeAntigravity, DisplayName: "Antigravity", Family: FamilyCLI, SortOrder: 130,
+		Kinds:    kindsChatOnly,
+		APIHosts: []string{"cloudcode-pa.googleapis.com"}, TokenHosts: []string{"oauth2.googleapis.com"},
+	},
+}
+
+// AllTypes returns every known provider type in display order.
+func AllTypes() []TypeInfo {
+	out := make([]TypeInfo, 0, len(registry))
+	for _, info := range registry {
+		out = append(out, info.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SortOrder < out[j].SortOrder })
+	return out
+}
+
+// Types returns all known provider types in display order.
+func Types() []string {
+	all := AllTypes()
+	out := make([]string, len(all))
+	for i, info := range all {
+		out[i] = info.Type
+	}
+	return out
+}
+
+// ResolveType returns the registry entry for providerType (case and whitespace
+// insensitive), or false for unknown types.
+func ResolveType(providerType string) (TypeInfo, bool) {
+	info, ok := registry[NormalizeType(providerType)]
+	return info.clone(), ok
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ez-api/foundation/modelcap"
+)
+
+func TestRegistryCoversTypes(t *testing.T) {
+	all := AllTypes()
+	if len(all) != len(Types()) {
+		t.Fatalf("AllTypes has %d entries, Types has %d", len(all), len(Types()))
+	}
+	for i, info := range all {
+		if info.DisplayName == "" || info.Family == "" {
+			t.Errorf("%s: missing display name or family", info.Type)
+		}
+		if i > 0 && info.SortOrder <= all[i-1].SortOrder {
+			t.Errorf("%s: sort order %d not after %s", info.Type, info.SortOrder, all[i-1].Type)
+		}
+		if len(info.Kinds) == 0 {
+			t.Errorf("%s: no supported kinds", info.Type)
+		}
+		if len(info.APIHosts) == 0 && info.Type != TypeCompatible && info.Type != TypeVertex {
+			t.Errorf("%s: no default API hosts", info.Type)
+		}
+		if (info.Family == FamilyGoogle) != IsGoogleFamily(info.Type) {
+			t.Errorf("%s: family %q disagrees with IsGoogleFamily", info.Type, info.Family)
+		}
+	}
+	for _, typ := range Types() {
+		if _, ok := ResolveType(typ); !ok {
+			t.Errorf("%s: not in registry", typ)
+		}
+	}
+}
+
+func TestResolveType(t *testing.T) {
+	info, ok := ResolveType("  Vertex ")
+	if !ok || info.Type != TypeVertex || info.DisplayName != "Google Vertex AI" {
+		t.Errorf("ResolveType(Vertex) = %+v, %v", info, ok)
+	}
+	info.Kinds[0], info.EnvVars[0] = modelcap.KindOther, "MUTATED"
+	if again, _ := ResolveType(TypeVertex); again.Kinds[0] != modelcap.KindChat || again.EnvVars[0] != googleADCEnv {
+		t.Error("ResolveType must return copies of registry slices")
+	}
+	if _, ok := ResolveType("bedrock"); ok {
+		t.Error("unknown type resolved")
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Snapshot is the CP-published view of a single provider consumed by DP.
+type Snapshot struct {
+	ID             uint     `json:"id"`
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	BaseURL        string   `json:"base_url"`
+	APIKey         string   `json:"api_key"`
+	GoogleProject  string   `json:"google_project,omitempty"`
+	GoogleLocation string   `json:"google_location,omitempty"`
+	GroupID        uint     `json:"group_id"`
+	Group          string   `json:"group"`
+	Models         []string `json:"models"`
+	Status         string   `json:"status"`
+	AutoBan        bool     `json:"auto_ban"`
+}
+
+func (s Snapshot) Validate() error {
+	if s.ID == 0 {
+		return errors.New("id required")
+	}
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.New("name required")
+	}
+	if NormalizeType(s.Type) == "" {
+		return errors.New("type required")
+	}
+	for i, m := range s.Models {
+		if strings.TrimSpace(m) == "" {
+			return fmt.Errorf("models[%d] empty", i)
+		}
+	}
+	return nil
+}
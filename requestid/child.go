@@ -0,0 +1,55 @@
+package requestid
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MaxLen bounds the length of a child id produced by NewChild.
+const MaxLen = 64
+
+const childSep = "-"
+
+// NewChild derives the id for the seq-th attempt of parent: parent + "-" + base36(seq).
+// If the result would exceed MaxLen, parent is truncated from the end (and stripped of a
+// trailing "-") so the suffix always survives. A New id is far shorter than MaxLen, so a
+// chain rooted in one keeps its root and Parent still recovers it. Negative seq is
+// treated as 0.
+func NewChild(parent string, seq int) string {
+	if seq < 0 {
+		seq = 0
+	}
+	suffix := childSep + strconv.FormatInt(int64(seq), 36)
+	if len(parent)+len(suffix) > MaxLen {
+		// Drop a dangling separator so the result still parses as root plus suffixes.
+		parent = strings.TrimRight(parent[:MaxLen-len(suffix)], childSep)
+	}
+	return parent + suffix
+}
+
+// Parent returns the root request id of a child id: a New id followed by one or more
+// NewChild suffixes is stripped of all of them. Any other id, such as a client-supplied
+// UUID, is returned unchanged.
+func Parent(id string) string {
+	if len(id) <= Len || !Valid(id[:Len]) || !strings.HasPrefix(id[Len:], childSep) {
+		return id
+	}
+	for _, part := range strings.Split(id[Len+len(childSep):], childSep) {
+		if !isBase36(part) {
+			return id
+		}
+	}
+	return id[:Len]
+}
+
+func isBase36(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'z') {
+			return false
+		}
+	}
+	return true
+}
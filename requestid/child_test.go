@@ -0,0 +1,126 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewChild(t *testing.T) {
+	root := New()
+	tests := []struct {
+		parent string
+		seq    int
+		want   string
+	}{
+		{root, 1, root + "-1"},
+		{root, 35, root + "-z"},
+		{root, 36, root + "-10"},
+		{root, -3, root + "-0"},
+	}
+	for _, tt := range tests {
+		got := NewChild(tt.parent, tt.seq)
+		if got != tt.want {
+			t.Errorf("NewChild(%q, %d) = %q, want %q", tt.parent, tt.seq, got, tt.want)
+		}
+		if p := Parent(got); p != tt.parent {
+			t.Errorf("Parent(%q) = %q, want %q", got, p, tt.parent)
+		}
+	}
+	if got := NewChild("abc", 2); got != "abc-2" {
+		t.Errorf("NewChild(abc, 2) = %q", got)
+	}
+}
+
+func TestParentReturnsRoot(t *testing.T) {
+	root := New()
+	grandchild := NewChild(NewChild(root, 2), 1)
+	if grandchild != root+"-2-1" {
+		t.Fatalf("grandchild = %q", grandchild)
+	}
+	if p := Parent(grandchild); p != root {
+		t.Errorf("Parent(%q) = %q, want root %q", grandchild, p, root)
+	}
+}
+
+func TestNewChildTruncatesParent(t *testing.T) {
+	parent := strings.Repeat("a", MaxLen)
+	got := NewChild(parent, 36*36)
+	if len(got) != MaxLen {
+		t.Fatalf("len = %d, want %d", len(got), MaxLen)
+	}
+	if !strings.HasSuffix(got, "-100") {
+		t.Errorf("suffix lost: %q", got)
+	}
+
+	// Deep chains are truncated in their suffixes; the root survives.
+	root := New()
+	id := root
+	for seq := 0; seq < 40; seq++ {
+		id = NewChild(id, seq*37)
+		if len(id) > MaxLen {
+			t.Fatalf("len(%q) = %d, want <= %d", id, len(id), MaxLen)
+		}
+		if p := Parent(id); p != root {
+			t.Fatalf("Parent(%q) = %q, want root", id, p)
+		}
+	}
+
+	exact := strings.Repeat("b", MaxLen-2)
+	if got := NewChild(exact, 1); got != exact+"-1" {
+		t.Errorf("id at the limit should not be truncated, got %q", got)
+	}
+}
+
+func TestParentWithoutSuffix(t *testing.T) {
+	root := New()
+	for _, id := range []string{
+		"", "abc", "abc-", "-1", "abc-X", "abc-2",
+		"550e8400-e29b-41d4-a716-446655440000", // client UUID
+		"client-req-42",
+		root, root + "-", root + "-1-", root + "-X", root + "_1",
+	} {
+		if got := Parent(id); got != id {
+			t.Errorf("Parent(%q) = %q, want unchanged", id, got)
+		}
+	}
+}
+
+func TestTransportChildIDs(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(HeaderName))
+	}))
+	defer srv.Close()
+
+	root := New()
+	type ctxKey struct{}
+	idFrom := func(ctx context.Context) string {
+		id, _ := ctx.Value(ctxKey{}).(string)
+		return id
+	}
+	client := &http.Client{Transport: NewTransport(nil, idFrom, WithChildIDs())}
+
+	ctx := WithAttemptCounter(context.WithValue(context.Background(), ctxKey{}, root))
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	plain, _ := http.NewRequestWithContext(context.WithValue(context.Background(), ctxKey{}, root), http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	want := []string{root + "-1", root + "-2", root}
+	if strings.Join(seen, ",") != strings.Join(want, ",") {
+		t.Errorf("stamped ids = %v, want %v", seen, want)
+	}
+}
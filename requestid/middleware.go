@@ -0,0 +1,86 @@
+package requestid
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// MiddlewareOption configures Middleware.
+type MiddlewareOption func(*middleware)
+
+type middleware struct {
+	replay       *replayCache
+	rejectReplay bool
+	onReplay     func(id string)
+	logger       *slog.Logger
+}
+
+// WithReplayWindow remembers up to n inbound ids for ttl. An inbound id seen again
+// within that window is replaced with a fresh one (or rejected, see WithRejectReplay),
+// so downstream dedup-by-request-id does not collapse distinct requests.
+func WithReplayWindow(n int, ttl time.Duration) MiddlewareOption {
+	return func(m *middleware) {
+		if n > 0 && ttl > 0 {
+			m.replay = newReplayCache(n, ttl)
+		}
+	}
+}
+
+// WithRejectReplay makes replayed ids fail with 409 Conflict instead of being regenerated.
+func WithRejectReplay() MiddlewareOption {
+	return func(m *middleware) {
+		m.rejectReplay = true
+	}
+}
+
+// WithReplayHook is called with every replayed inbound id, e.g. to increment a metric.
+func WithReplayHook(fn func(id string)) MiddlewareOption {
+	return func(m *middleware) {
+		m.onReplay = fn
+	}
+}
+
+// WithLogger sets the logger for replay warnings; the default is slog.Default().
+func WithLogger(l *slog.Logger) MiddlewareOption {
+	return func(m *middleware) {
+		m.logger = l
+	}
+}
+
+func (m *middleware) log() *slog.Logger {
+	if m.logger != nil {
+		return m.logger
+	}
+	return slog.Default()
+}
+
+// Middleware ensures every request carries a request id: the inbound X-Request-ID is
+// kept (or a new one generated) and set on both the request and the response headers.
+func Middleware(opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	m := &middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := Extract(r.Header.Get)
+			if id == "" {
+				id = New()
+			} else if m.replay != nil && m.replay.seen(id) {
+				m.log().Warn("replayed request id", "request_id", id, "rejected", m.rejectReplay)
+				if m.onReplay != nil {
+					m.onReplay(id)
+				}
+				if m.rejectReplay {
+					http.Error(w, "request id reused", http.StatusConflict)
+					return
+				}
+				id = New()
+			}
+			r.Header.Set(HeaderName, id)
+			w.Header().Set(HeaderName, id)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package requestid
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func serve(h http.Handler, id string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if id != "" {
+		req.Header.Set(HeaderName, id)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareAssignsID(t *testing.T) {
+	var seen string
+	h := Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Get(HeaderName)
+	}))
+
+	rec := serve(h, "")
+	if !Valid(seen) || rec.Header().Get(HeaderName) != seen {
+		t.Errorf("generated id %q, response header %q", seen, rec.Header().Get(HeaderName))
+	}
+	serve(h, "client-id")
+	if seen != "client-id" {
+		t.Errorf("inbound id not kept: %q", seen)
+	}
+	// Without a replay window, repeats pass through.
+	serve(h, "client-id")
+	if seen != "client-id" {
+		t.Errorf("repeat without replay window changed id: %q", seen)
+	}
+}
+
+func TestMiddlewareReplayRegenerates(t *testing.T) {
+	var seen string
+	var replays []string
+	h := Middleware(WithReplayWindow(8, time.Minute), WithReplayHook(func(id string) {
+		replays = append(replays, id)
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Get(HeaderName)
+	}))
+
+	serve(h, "dup")
+	rec := serve(h, "dup")
+	if rec.Code != http.StatusOK || seen == "dup" || !Valid(seen) {
+		t.Errorf("replayed id should be regenerated, got %q (code %d)", seen, rec.Code)
+	}
+	if len(replays) != 1 || replays[0] != "dup" {
+		t.Errorf("replay hook calls = %v", replays)
+	}
+}
+
+func TestMiddlewareReplayLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	h := Middleware(WithReplayWindow(8, time.Minute), WithLogger(logger))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	serve(h, "dup")
+	serve(h, "dup")
+	if !bytes.Contains(buf.Bytes(), []byte("replayed request id")) || !bytes.Contains(buf.Bytes(), []byte("request_id=dup")) {
+		t.Errorf("replay warning not written to the injected logger: %q", buf.String())
+	}
+}
+
+func TestMiddlewareReplayRejects(t *testing.T) {
+	calls := 0
+	h := Middleware(WithReplayWindow(8, time.Minute), WithRejectReplay())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+
+	if rec := serve(h, "dup"); rec.Code != http.StatusOK {
+		t.Fatalf("first request: code %d", rec.Code)
+	}
+	if rec := serve(h, "dup"); rec.Code != http.StatusConflict {
+		t.Errorf("replay: code %d, want 409", rec.Code)
+	}
+	if calls != 1 {
+		t.Errorf("handler calls = %d, want 1", calls)
+	}
+}
+
+func TestReplayCacheEviction(t *testing.T) {
+	c := newReplayCache(2, time.Minute)
+	c.seen("a")
+	c.seen("b")
+	c.seen("a") // refresh a; b is now least recent
+	c.seen("c") // evicts b
+
+	if c.len() != 2 {
+		t.Fatalf("len = %d, want 2", c.len())
+	}
+	if c.seen("b") {
+		t.Error("b should have been evicted")
+	}
+	if !c.seen("b") {
+		t.Error("b should be remembered again")
+	}
+}
+
+func TestReplayCacheTTL(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c := newReplayCache(4, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.seen("a")
+	now = now.Add(59 * time.Second)
+	if !c.seen("a") {
+		t.Error("a should still be within the window")
+	}
+	now = now.Add(2 * time.Second)
+	if c.seen("a") {
+		t.Error("a should have expired")
+	}
+	if !c.seen("a") {
+		t.Error("a should be remembered after re-recording")
+	}
+}
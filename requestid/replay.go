@@ -0,0 +1,65 @@
+package requestid
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// replayCache is a bounded LRU of recently seen ids with per-entry expiry.
+// All operations are O(1) under a single short-held mutex.
+type replayCache struct {
+	mu    sync.Mutex
+	cap   int
+	ttl   time.Duration
+	now   func() time.Time
+	order *list.List // front = most recently seen
+	items map[string]*list.Element
+}
+
+type replayEntry struct {
+	id      string
+	expires time.Time
+}
+
+func newReplayCache(capacity int, ttl time.Duration) *replayCache {
+	return &replayCache{
+		cap:   capacity,
+		ttl:   ttl,
+		now:   time.Now,
+		order: list.New(),
+		items: make(map[string]*list.Element, capacity),
+	}
+}
+
+// seen records id and reports whether it was already present and unexpired.
+func (c *replayCache) seen(id string) bool {
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[id]; ok {
+		e := el.Value.(*replayEntry)
+		if now.Before(e.expires) {
+			c.order.MoveToFront(el)
+			return true
+		}
+		e.expires = now.Add(c.ttl)
+		c.order.MoveToFront(el)
+		return false
+	}
+
+	if c.order.Len() >= c.cap {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*replayEntry).id)
+	}
+	c.items[id] = c.order.PushFront(&replayEntry{id: id, expires: now.Add(c.ttl)})
+	return false
+}
+
+func (c *replayCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
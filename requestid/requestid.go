@@ -2,13 +2,18 @@ package requestid
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
 const HeaderName = "X-Request-ID"
 
+// Len is the length of a request_id produced by New: 16 random bytes as lower hex.
+const Len = 32
+
 // Extract returns request_id from headers (X-Request-ID / X-Request-Id), trimmed.
 // The getter is typically http.Header.Get or gin.Context.GetHeader.
 func Extract(get func(string) string) string {
@@ -22,12 +27,30 @@ func Extract(get func(string) string) string {
 	return id
 }
 
+var fallbackSeq atomic.Uint64
+
 // New generates a new request_id as lower hex.
 func New() string {
 	var b [16]byte
 	if _, err := rand.Read(b[:]); err != nil {
-		return hex.EncodeToString([]byte(time.Now().Format(time.RFC3339Nano)))
+		// Keep the format contract even without entropy: time + process-local sequence.
+		binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixNano()))
+		binary.BigEndian.PutUint64(b[8:], fallbackSeq.Add(1))
 	}
 	return hex.EncodeToString(b[:])
 }
 
+// Valid reports whether id has the format produced by New: exactly 32 lowercase hex characters.
+// Downstream systems (log pipeline, billing correlation) rely on this format.
+func Valid(id string) bool {
+	if len(id) != Len {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+// Transport stamps HeaderName on outgoing requests that don't already carry one.
+type Transport struct {
+	base     http.RoundTripper
+	idFrom   func(ctx context.Context) string
+	childIDs bool
+}
+
+// TransportOption configures a Transport.
+type TransportOption func(*Transport)
+
+// WithChildIDs makes the Transport stamp NewChild(id, n) for the n-th attempt made with a
+// context prepared by WithAttemptCounter, so retries can be told apart upstream.
+// Requests without a counter get the plain id.
+func WithChildIDs() TransportOption {
+	return func(t *Transport) {
+		t.childIDs = true
+	}
+}
+
+// NewTransport wraps base (http.DefaultTransport if nil). idFrom returns the request id
+// for the outgoing request's context, e.g. logging.RequestIDFromContext.
+func NewTransport(base http.RoundTripper, idFrom func(ctx context.Context) string, opts ...TransportOption) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &Transport{base: base, idFrom: idFrom}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(HeaderName) != "" || t.idFrom == nil {
+		return t.base.RoundTrip(req)
+	}
+	id := t.idFrom(req.Context())
+	if id == "" {
+		return t.base.RoundTrip(req)
+	}
+	if t.childIDs {
+		if n, ok := req.Context().Value(attemptKey{}).(*atomic.Int64); ok {
+			id = NewChild(id, int(n.Add(1)))
+		}
+	}
+	// RoundTrippers must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set(HeaderName, id)
+	return t.base.RoundTrip(req)
+}
+
+type attemptKey struct{}
+
+// WithAttemptCounter attaches a per-request attempt counter used by WithChildIDs.
+// Attempts are numbered from 1.
+func WithAttemptCounter(ctx context.Context) context.Context {
+	return context.WithValue(ctx, attemptKey{}, new(atomic.Int64))
+}
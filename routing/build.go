@@ -0,0 +1,116 @@
+package routing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ez-api/foundation/group"
+)
+
+// Snapshot and candidate status values. BuildSnapshot writes active and error;
+// disabled is set by CP for candidates an operator has switched off.
+const (
+	StatusActive   = "active"
+	StatusError    = "error"
+	StatusDisabled = "disabled"
+)
+
+// CandidateInput is everything CP knows about one provider group candidate before resolution.
+type CandidateInput struct {
+	GroupID        uint
+	RouteGroup     string
+	Weight         int
+	SelectorType   SelectorType
+	SelectorValue  string
+	TimeoutMs      int
+	MaxRetries     int
+	ProviderModels map[string][]string // provider_id -> model listing
+}
+
+// BuildIssue describes a problem found while building a snapshot, for CP to render as a warning.
+type BuildIssue struct {
+	GroupID    uint
+	ProviderID string // empty for candidate-level issues
+	Code       string // CandidateErrorConfig | CandidateErrorNoProvider
+	Message    string
+}
+
+func (i BuildIssue) String() string {
+	if i.ProviderID == "" {
+		return fmt.Sprintf("group %d: %s: %s", i.GroupID, i.Code, i.Message)
+	}
+	return fmt.Sprintf("group %d provider %s: %s: %s", i.GroupID, i.ProviderID, i.Code, i.Message)
+}
+
+// BuildSnapshot resolves upstream models for every candidate and assembles the snapshot for ref.
+// Candidates that cannot be served are kept and marked with config_error (no provider model
+// matched the selector) or no_provider (no providers at all) rather than dropped.
+// Route groups are normalized, candidates are sorted by route group then group id,
+// and UpdatedAt is set from now.
+func BuildSnapshot(ref ModelRef, inputs []CandidateInput, now time.Time) (BindingSnapshot, []BuildIssue) {
+	snap := BindingSnapshot{
+		Namespace:   strings.TrimSpace(ref.Namespace),
+		PublicModel: strings.TrimSpace(ref.PublicModel),
+		UpdatedAt:   now.Unix(),
+		Candidates:  make([]BindingCandidate, 0, len(inputs)),
+	}
+
+	var issues []BuildIssue
+	active := false
+	for _, in := range inputs {
+		c := BindingCandidate{
+			GroupID:       in.GroupID,
+			RouteGroup:    group.Normalize(in.RouteGroup),
+			Weight:        in.Weight,
+			SelectorType:  string(in.SelectorType),
+			SelectorValue: strings.TrimSpace(in.SelectorValue),
+			TimeoutMs:     in.TimeoutMs,
+			MaxRetries:    in.MaxRetries,
+			Upstreams:     make(map[string]string, len(in.ProviderModels)),
+		}
+
+		providerIDs := make([]string, 0, len(in.ProviderModels))
+		for id := range in.ProviderModels {
+			providerIDs = append(providerIDs, id)
+		}
+		sort.Strings(providerIDs)
+
+		for _, id := range providerIDs {
+			upstream, err := ResolveUpstreamModel(in.SelectorType, in.SelectorValue, snap.PublicModel, in.ProviderModels[id])
+			if err != nil {
+				issues = append(issues, BuildIssue{GroupID: in.GroupID, ProviderID: id, Code: CandidateErrorConfig, Message: err.Error()})
+				continue
+			}
+			c.Upstreams[id] = upstream
+		}
+
+		switch {
+		case len(providerIDs) == 0:
+			c.Status, c.Error = StatusError, CandidateErrorNoProvider
+			issues = append(issues, BuildIssue{GroupID: in.GroupID, Code: CandidateErrorNoProvider, Message: "no providers in group"})
+		case len(c.Upstreams) == 0:
+			c.Status, c.Error = StatusError, CandidateErrorConfig
+			issues = append(issues, BuildIssue{GroupID: in.GroupID, Code: CandidateErrorConfig, Message: "no provider resolved an upstream model"})
+		default:
+			c.Status = StatusActive
+			active = true
+		}
+		snap.Candidates = append(snap.Candidates, c)
+	}
+
+	sort.SliceStable(snap.Candidates, func(i, j int) bool {
+		a, b := snap.Candidates[i], snap.Candidates[j]
+		if a.RouteGroup != b.RouteGroup {
+			return a.RouteGroup < b.RouteGroup
+		}
+		return a.GroupID < b.GroupID
+	})
+
+	snap.Status = StatusError
+	if active {
+		snap.Status = StatusActive
+	}
+	return snap, issues
+}
@@ -0,0 +1,84 @@
+package routing
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestBuildSnapshot_Golden(t *testing.T) {
+	ref := ModelRef{Namespace: "ns", PublicModel: "gpt-4o"}
+	inputs := []CandidateInput{
+		{
+			GroupID:    9,
+			RouteGroup: " Premium ",
+			Weight:     100,
+			ProviderModels: map[string][]string{
+				"301": {"gpt-4o", "gpt-4o-mini"},
+				"302": {"gpt-4o-mini"},
+			},
+		},
+		{
+			GroupID:       7,
+			RouteGroup:    "",
+			Weight:        80,
+			SelectorType:  SelectorNormalizeExact,
+			SelectorValue: "GPT-4o",
+			TimeoutMs:     30000,
+			ProviderModels: map[string][]string{
+				"101": {"openai/gpt-4o"},
+				"102": {"gpt-4o"},
+			},
+		},
+		{
+			GroupID:        8,
+			RouteGroup:     "default",
+			Weight:         20,
+			SelectorType:   SelectorRegex,
+			SelectorValue:  "^claude",
+			ProviderModels: map[string][]string{"201": {"gpt-4o"}},
+		},
+		{GroupID: 10, RouteGroup: "default"},
+	}
+
+	snap, issues := BuildSnapshot(ref, inputs, time.Unix(1734464000, 0))
+	if err := snap.Validate(); err != nil {
+		t.Fatalf("built snapshot is invalid: %v", err)
+	}
+
+	got, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	got = append(got, '\n')
+	const golden = "testdata/build_snapshot.json"
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("snapshot differs from %s:\n%s", golden, got)
+	}
+
+	wantIssues := []string{
+		"group 9 provider 302: config_error: no match for \"gpt-4o\"",
+		"group 8 provider 201: config_error: no regex match for \"^claude\"",
+		"group 8: config_error: no provider resolved an upstream model",
+		"group 10: no_provider: no providers in group",
+	}
+	if len(issues) != len(wantIssues) {
+		t.Fatalf("issues = %v", issues)
+	}
+	for i, issue := range issues {
+		if issue.String() != wantIssues[i] {
+			t.Errorf("issue %d = %q, want %q", i, issue, wantIssues[i])
+		}
+	}
+}
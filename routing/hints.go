@@ -0,0 +1,218 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ez-api/foundation/group"
+)
+
+// Request headers carrying per-request routing hints.
+const (
+	HeaderRouteGroup    = "X-EZ-Route-Group"
+	HeaderPreferGroups  = "X-EZ-Prefer-Groups"  // comma-separated group ids
+	HeaderExcludeGroups = "X-EZ-Exclude-Groups" // comma-separated group ids
+	HeaderProvider      = "X-EZ-Provider"
+	HeaderUpstreamModel = "X-EZ-Upstream-Model"
+)
+
+// Bounds on hint header values.
+const (
+	MaxHintHeaderLen    = 256
+	MaxHintGroupIDs     = 16
+	MaxProviderIDLen    = 64
+	MaxUpstreamModelLen = 128
+)
+
+// RouteHints are per-request routing overrides supplied by the client.
+type RouteHints struct {
+	RouteGroup       string
+	PreferGroupIDs   []uint
+	ExcludeGroupIDs  []uint
+	PinProviderID    string
+	PinUpstreamModel string
+}
+
+// ErrPinnedTargetIneligible is matched (errors.Is) by *PinError.
+var ErrPinnedTargetIneligible = errors.New("pinned routing target not eligible")
+
+// PinError reports that a pinned provider/upstream model has no eligible candidate.
+// Pinning fails closed: callers must not fall back to unpinned routing.
+type PinError struct {
+	ProviderID    string
+	UpstreamModel string
+}
+
+func (e *PinError) Error() string {
+	return fmt.Sprintf("pinned routing target not eligible: provider %q, upstream model %q", e.ProviderID, e.UpstreamModel)
+}
+
+func (e *PinError) Is(target error) bool { return target == ErrPinnedTargetIneligible }
+
+var providerIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]*$`)
+
+// ParseRouteHints reads RouteHints from request headers; get is typically http.Header.Get.
+// Every value is validated and length-bounded; absent headers leave fields empty.
+func ParseRouteHints(get func(string) string) (RouteHints, error) {
+	var h RouteHints
+	if get == nil {
+		return h, nil
+	}
+	header := func(name string) (string, error) {
+		v := strings.TrimSpace(get(name))
+		if len(v) > MaxHintHeaderLen {
+			return "", fmt.Errorf("%s: value longer than %d bytes", name, MaxHintHeaderLen)
+		}
+		return v, nil
+	}
+
+	v, err := header(HeaderRouteGroup)
+	if err != nil {
+		return RouteHints{}, err
+	}
+	if v != "" {
+		g := group.Normalize(v)
+		if err := group.Validate(g); err != nil {
+			return RouteHints{}, fmt.Errorf("%s: %w", HeaderRouteGroup, err)
+		}
+		h.RouteGroup = g
+	}
+
+	for _, f := range []struct {
+		name string
+		dst  *[]uint
+	}{{HeaderPreferGroups, &h.PreferGroupIDs}, {HeaderExcludeGroups, &h.ExcludeGroupIDs}} {
+		v, err := header(f.name)
+		if err != nil {
+			return RouteHints{}, err
+		}
+		ids, err := parseGroupIDs(v)
+		if err != nil {
+			return RouteHints{}, fmt.Errorf("%s: %w", f.name, err)
+		}
+		*f.dst = ids
+	}
+
+	if v, err = header(HeaderProvider); err != nil {
+		return RouteHints{}, err
+	}
+	if v != "" && (len(v) > MaxProviderIDLen || !providerIDPattern.MatchString(v)) {
+		return RouteHints{}, fmt.Errorf("%s: invalid provider id", HeaderProvider)
+	}
+	h.PinProviderID = v
+
+	if v, err = header(HeaderUpstreamModel); err != nil {
+		return RouteHints{}, err
+	}
+	if v != "" && (len(v) > MaxUpstreamModelLen || strings.ContainsFunc(v, func(r rune) bool { return r <= ' ' || r == 0x7f })) {
+		return RouteHints{}, fmt.Errorf("%s: invalid upstream model", HeaderUpstreamModel)
+	}
+	h.PinUpstreamModel = v
+
+	if err := h.Validate(); err != nil {
+		return RouteHints{}, err
+	}
+	return h, nil
+}
+
+// Validate rejects contradictory hints.
+func (h RouteHints) Validate() error {
+	excluded := make(map[uint]struct{}, len(h.ExcludeGroupIDs))
+	for _, id := range h.ExcludeGroupIDs {
+		excluded[id] = struct{}{}
+	}
+	for _, id := range h.PreferGroupIDs {
+		if _, ok := excluded[id]; ok {
+			return fmt.Errorf("group %d is both preferred and excluded", id)
+		}
+	}
+	return nil
+}
+
+func parseGroupIDs(v string) ([]uint, error) {
+	if v == "" {
+		return nil, nil
+	}
+	parts := strings.Split(v, ",")
+	if len(parts) > MaxHintGroupIDs {
+		return nil, fmt.Errorf("more than %d group ids", MaxHintGroupIDs)
+	}
+	ids := make([]uint, 0, len(parts))
+	for _, p := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(p), 10, 32)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("invalid group id %q", strings.TrimSpace(p))
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// ApplyHints narrows candidates to those eligible under h, in routing order:
+// ineligible candidates (see BindingCandidate.Eligible) and excluded groups are dropped, RouteGroup (if set) must match,
+// preferred groups move to the front (in hint order), and pins keep only candidates
+// serving the pinned provider/upstream model, with Upstreams narrowed accordingly.
+// If a pin matches nothing, it returns a *PinError rather than ignoring the pin.
+func ApplyHints(candidates []BindingCandidate, h RouteHints) ([]BindingCandidate, error) {
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+	excluded := make(map[uint]struct{}, len(h.ExcludeGroupIDs))
+	for _, id := range h.ExcludeGroupIDs {
+		excluded[id] = struct{}{}
+	}
+
+	out := make([]BindingCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if !c.Eligible() {
+			continue
+		}
+		if _, ok := excluded[c.GroupID]; ok {
+			continue
+		}
+		if h.RouteGroup != "" && c.RouteGroup != h.RouteGroup {
+			continue
+		}
+		if h.PinProviderID != "" || h.PinUpstreamModel != "" {
+			upstreams := make(map[string]string)
+			for providerID, upstream := range c.Upstreams {
+				if h.PinProviderID != "" && providerID != h.PinProviderID {
+					continue
+				}
+				if h.PinUpstreamModel != "" && upstream != h.PinUpstreamModel {
+					continue
+				}
+				upstreams[providerID] = upstream
+			}
+			if len(upstreams) == 0 {
+				continue
+			}
+			c.Upstreams = upstreams
+		}
+		out = append(out, c)
+	}
+
+	if len(out) == 0 && (h.PinProviderID != "" || h.PinUpstreamModel != "") {
+		return nil, &PinError{ProviderID: h.PinProviderID, UpstreamModel: h.PinUpstreamModel}
+	}
+	if len(h.PreferGroupIDs) > 0 {
+		rank := make(map[uint]int, len(h.PreferGroupIDs))
+		for i, id := range h.PreferGroupIDs {
+			if _, dup := rank[id]; !dup {
+				rank[id] = i
+			}
+		}
+		order := func(c BindingCandidate) int {
+			if r, ok := rank[c.GroupID]; ok {
+				return r
+			}
+			return len(h.PreferGroupIDs)
+		}
+		slices.SortStableFunc(out, func(a, b BindingCandidate) int { return order(a) - order(b) })
+	}
+	return out, nil
+}
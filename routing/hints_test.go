@@ -0,0 +1,149 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseRouteHints(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    RouteHints
+		wantErr string
+	}{
+		{name: "none", headers: nil},
+		{
+			name: "all",
+			headers: map[string]string{
+				HeaderRouteGroup:    " Canary ",
+				HeaderPreferGroups:  "3, 1",
+				HeaderExcludeGroups: "7",
+				HeaderProvider:      "p-7",
+				HeaderUpstreamModel: "gpt-4o-2024-08-06",
+			},
+			want: RouteHints{RouteGroup: "canary", PreferGroupIDs: []uint{3, 1}, ExcludeGroupIDs: []uint{7}, PinProviderID: "p-7", PinUpstreamModel: "gpt-4o-2024-08-06"},
+		},
+		{name: "conflicting prefer/exclude", headers: map[string]string{HeaderPreferGroups: "1,2", HeaderExcludeGroups: "2"}, wantErr: "both preferred and excluded"},
+		{name: "bad group id", headers: map[string]string{HeaderPreferGroups: "1,x"}, wantErr: HeaderPreferGroups},
+		{name: "zero group id", headers: map[string]string{HeaderExcludeGroups: "0"}, wantErr: HeaderExcludeGroups},
+		{name: "too many ids", headers: map[string]string{HeaderPreferGroups: strings.Repeat("1,", MaxHintGroupIDs) + "1"}, wantErr: "more than"},
+		{name: "bad route group", headers: map[string]string{HeaderRouteGroup: "ca nary"}, wantErr: HeaderRouteGroup},
+		{name: "bad provider", headers: map[string]string{HeaderProvider: "p 7"}, wantErr: HeaderProvider},
+		{name: "provider too long", headers: map[string]string{HeaderProvider: strings.Repeat("p", MaxProviderIDLen+1)}, wantErr: HeaderProvider},
+		{name: "control char in model", headers: map[string]string{HeaderUpstreamModel: "gpt\x00"}, wantErr: HeaderUpstreamModel},
+		{name: "oversized header", headers: map[string]string{HeaderUpstreamModel: strings.Repeat("m", MaxHintHeaderLen+1)}, wantErr: "longer than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdr := http.Header{}
+			for k, v := range tt.headers {
+				hdr.Set(k, v)
+			}
+			got, err := ParseRouteHints(hdr.Get)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.RouteGroup != tt.want.RouteGroup || got.PinProviderID != tt.want.PinProviderID || got.PinUpstreamModel != tt.want.PinUpstreamModel ||
+				!equalUints(got.PreferGroupIDs, tt.want.PreferGroupIDs) || !equalUints(got.ExcludeGroupIDs, tt.want.ExcludeGroupIDs) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func equalUints(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hintCandidates() []BindingCandidate {
+	return []BindingCandidate{
+		{GroupID: 1, RouteGroup: "default", Upstreams: map[string]string{"p-1": "gpt-4o", "p-2": "gpt-4o"}},
+		{GroupID: 2, RouteGroup: "canary", Upstreams: map[string]string{"p-7": "gpt-4o-2024-08-06"}},
+		{GroupID: 3, RouteGroup: "default", Upstreams: map[string]string{"p-3": "gpt-4o"}},
+		{GroupID: 4, RouteGroup: "default", Error: CandidateErrorNoProvider},
+		{GroupID: 5, RouteGroup: "default", Status: StatusDisabled, Upstreams: map[string]string{"p-5": "gpt-4o-mini"}},
+		{GroupID: 6, RouteGroup: "default", Status: StatusError, Upstreams: map[string]string{"p-6": "gpt-4o-mini"}},
+	}
+}
+
+func groupIDs(cands []BindingCandidate) []uint {
+	ids := make([]uint, len(cands))
+	for i, c := range cands {
+		ids[i] = c.GroupID
+	}
+	return ids
+}
+
+func TestApplyHints(t *testing.T) {
+	tests := []struct {
+		name  string
+		hints RouteHints
+		want  []uint
+	}{
+		{"no hints drops ineligible", RouteHints{}, []uint{1, 2, 3}},
+		{"route group", RouteHints{RouteGroup: "canary"}, []uint{2}},
+		{"exclude", RouteHints{ExcludeGroupIDs: []uint{1}}, []uint{2, 3}},
+		{"prefer reorders", RouteHints{PreferGroupIDs: []uint{3, 2}}, []uint{3, 2, 1}},
+		{"pin provider", RouteHints{PinProviderID: "p-2"}, []uint{1}},
+		{"pin upstream model", RouteHints{PinUpstreamModel: "gpt-4o"}, []uint{1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyHints(hintCandidates(), tt.hints)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalUints(groupIDs(got), tt.want) {
+				t.Errorf("groups = %v, want %v", groupIDs(got), tt.want)
+			}
+		})
+	}
+
+	got, _ := ApplyHints(hintCandidates(), RouteHints{PinProviderID: "p-2"})
+	if len(got[0].Upstreams) != 1 || got[0].Upstreams["p-2"] != "gpt-4o" {
+		t.Errorf("pinned upstreams not narrowed: %v", got[0].Upstreams)
+	}
+	if len(hintCandidates()[0].Upstreams) != 2 {
+		t.Error("input candidates must not be modified")
+	}
+}
+
+func TestApplyHintsPinFailsClosed(t *testing.T) {
+	tests := []RouteHints{
+		{PinProviderID: "p-9"},
+		{PinProviderID: "p-7", RouteGroup: "default"},
+		{PinProviderID: "p-7", ExcludeGroupIDs: []uint{2}},
+		{PinProviderID: "p-1", PinUpstreamModel: "gpt-4o-2024-08-06"},
+		{PinProviderID: "p-5"}, // disabled candidate
+		{PinProviderID: "p-6"}, // error status without an error code
+		{PinUpstreamModel: "gpt-4o-mini"},
+	}
+	for _, h := range tests {
+		_, err := ApplyHints(hintCandidates(), h)
+		var pe *PinError
+		if !errors.As(err, &pe) || !errors.Is(err, ErrPinnedTargetIneligible) || pe.ProviderID != h.PinProviderID {
+			t.Errorf("ApplyHints(%+v) err = %v, want *PinError", h, err)
+		}
+	}
+
+	if _, err := ApplyHints(hintCandidates(), RouteHints{PreferGroupIDs: []uint{1}, ExcludeGroupIDs: []uint{1}}); err == nil {
+		t.Error("expected error for conflicting hints")
+	}
+}
@@ -0,0 +1,62 @@
+package routing
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Issue severities reported by ValidateAgainstProviders.
+const (
+	SeverityError   = "error"   // the candidate has no known provider left and cannot serve
+	SeverityWarning = "warning" // the candidate still has at least one known provider
+)
+
+// Issue is a problem found in a snapshot that does not make it structurally invalid.
+type Issue struct {
+	Severity      string
+	GroupID       uint
+	ProviderID    string
+	UpstreamModel string
+	Message       string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: group %d provider %s: %s", i.Severity, i.GroupID, i.ProviderID, i.Message)
+}
+
+// ValidateAgainstProviders reports every candidate upstream in b whose provider id is
+// not in knownProviderIDs. Issues are errors when none of a candidate's providers are
+// known and warnings otherwise. Candidates already marked with an Error are skipped.
+// b is not modified.
+func ValidateAgainstProviders(b BindingSnapshot, knownProviderIDs map[string]struct{}) []Issue {
+	var issues []Issue
+	for _, c := range b.Candidates {
+		if c.Error != "" || len(c.Upstreams) == 0 {
+			continue
+		}
+		var unknown []string
+		for id := range c.Upstreams {
+			if _, ok := knownProviderIDs[id]; !ok {
+				unknown = append(unknown, id)
+			}
+		}
+		if len(unknown) == 0 {
+			continue
+		}
+		sort.Strings(unknown)
+		severity := SeverityWarning
+		if len(unknown) == len(c.Upstreams) {
+			severity = SeverityError
+		}
+		for _, id := range unknown {
+			issues = append(issues, Issue{
+				Severity:      severity,
+				GroupID:       c.GroupID,
+				ProviderID:    id,
+				UpstreamModel: c.Upstreams[id],
+				Message:       "unknown provider id",
+			})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,57 @@
+package routing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateAgainstProviders(t *testing.T) {
+	snap := BindingSnapshot{
+		Namespace:   "ns",
+		PublicModel: "gpt-4o",
+		Candidates: []BindingCandidate{
+			{GroupID: 1, Upstreams: map[string]string{"101": "gpt-4o", "102": "gpt-4o"}},
+			{GroupID: 2, Upstreams: map[string]string{"201": "gpt-4o"}},
+			{GroupID: 3, Error: CandidateErrorNoProvider},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		known map[string]struct{}
+		want  []Issue
+	}{
+		{
+			name:  "all known",
+			known: map[string]struct{}{"101": {}, "102": {}, "201": {}},
+		},
+		{
+			name:  "partially known",
+			known: map[string]struct{}{"101": {}},
+			want: []Issue{
+				{Severity: SeverityWarning, GroupID: 1, ProviderID: "102", UpstreamModel: "gpt-4o", Message: "unknown provider id"},
+				{Severity: SeverityError, GroupID: 2, ProviderID: "201", UpstreamModel: "gpt-4o", Message: "unknown provider id"},
+			},
+		},
+		{
+			name:  "empty registry",
+			known: nil,
+			want: []Issue{
+				{Severity: SeverityError, GroupID: 1, ProviderID: "101", UpstreamModel: "gpt-4o", Message: "unknown provider id"},
+				{Severity: SeverityError, GroupID: 1, ProviderID: "102", UpstreamModel: "gpt-4o", Message: "unknown provider id"},
+				{Severity: SeverityError, GroupID: 2, ProviderID: "201", UpstreamModel: "gpt-4o", Message: "unknown provider id"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateAgainstProviders(snap, tt.known)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("issues = %+v, want %+v", got, tt.want)
+			}
+			if len(snap.Candidates[0].Upstreams) != 2 {
+				t.Error("snapshot was mutated")
+			}
+		})
+	}
+}
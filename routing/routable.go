@@ -0,0 +1,48 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Routability errors returned by ValidateRoutable.
+var (
+	ErrNoEligibleCandidate = errors.New("no eligible candidate")
+	ErrZeroTotalWeight     = errors.New("total eligible weight is zero")
+)
+
+// Eligible reports whether DP may select c: it carries no error, is not in error or
+// disabled status, and has at least one upstream.
+func (c BindingCandidate) Eligible() bool {
+	if c.Error != "" || len(c.Upstreams) == 0 {
+		return false
+	}
+	switch c.Status {
+	case StatusError, StatusDisabled:
+		return false
+	}
+	return true
+}
+
+// ValidateRoutable checks that DP can actually pick a candidate from b. It is a
+// publish-time guardrail, distinct from the structural Validate: a snapshot can be
+// well-formed and still route nothing. Selection is weighted, so a snapshot whose
+// eligible candidates all have weight 0 is rejected too.
+func ValidateRoutable(b BindingSnapshot) error {
+	eligible, total := 0, 0
+	for _, c := range b.Candidates {
+		if !c.Eligible() {
+			continue
+		}
+		eligible++
+		total += c.Weight
+	}
+	key := ModelRef{Namespace: b.Namespace, PublicModel: b.PublicModel}.Key()
+	if eligible == 0 {
+		return fmt.Errorf("%w: %s (%d candidates)", ErrNoEligibleCandidate, key, len(b.Candidates))
+	}
+	if total == 0 {
+		return fmt.Errorf("%w: %s (%d eligible candidates)", ErrZeroTotalWeight, key, eligible)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package routing
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestValidateRoutable(t *testing.T) {
+	up := map[string]string{"p1": "m1"}
+	cand := func(status, errCode string, weight int) BindingCandidate {
+		c := BindingCandidate{GroupID: 1, RouteGroup: "default", Status: status, Error: errCode, Weight: weight}
+		if errCode == "" {
+			c.Upstreams = up
+		}
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		cands []BindingCandidate
+		want  error
+	}{
+		{"no candidates", nil, ErrNoEligibleCandidate},
+		{"all zero weight", []BindingCandidate{cand(StatusActive, "", 0), cand(StatusActive, "", 0)}, ErrZeroTotalWeight},
+		{"all error", []BindingCandidate{cand(StatusError, CandidateErrorConfig, 50), cand(StatusError, CandidateErrorNoProvider, 50)}, ErrNoEligibleCandidate},
+		{"all disabled", []BindingCandidate{cand(StatusDisabled, "", 50), cand(StatusDisabled, "", 50)}, ErrNoEligibleCandidate},
+		{"all disabled zero weight", []BindingCandidate{cand(StatusDisabled, "", 0), cand(StatusDisabled, "", 0)}, ErrNoEligibleCandidate},
+		{"error and disabled", []BindingCandidate{cand(StatusError, CandidateErrorConfig, 50), cand(StatusDisabled, "", 50)}, ErrNoEligibleCandidate},
+		{"weighted only on ineligible", []BindingCandidate{cand(StatusDisabled, "", 100), cand(StatusActive, "", 0)}, ErrZeroTotalWeight},
+		{"error status without code", []BindingCandidate{cand(StatusError, "", 100)}, ErrNoEligibleCandidate},
+		{"one weighted eligible", []BindingCandidate{cand(StatusError, CandidateErrorConfig, 0), cand(StatusActive, "", 0), cand(StatusActive, "", 10)}, nil},
+		{"unset status", []BindingCandidate{cand("", "", 1)}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := BindingSnapshot{Namespace: "ns", PublicModel: "m", Candidates: tt.cands}
+			err := ValidateRoutable(snap)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateRoutable = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateRoutable = %v, want %v", err, tt.want)
+			}
+			if !strings.Contains(err.Error(), "ns.m") {
+				t.Errorf("error %q should name the binding", err)
+			}
+		})
+	}
+}
+
+func TestBindingTableRoutableCheck(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewBindingTable(WithRoutableCheck(slog.New(slog.NewTextHandler(&buf, nil))))
+
+	bad := BindingSnapshot{Namespace: "ns", PublicModel: "dead", Candidates: []BindingCandidate{
+		{GroupID: 1, Status: StatusDisabled, Upstreams: map[string]string{"p1": "m1"}},
+	}}
+	good := BindingSnapshot{Namespace: "ns", PublicModel: "live", Candidates: []BindingCandidate{
+		{GroupID: 1, Status: StatusActive, Weight: 1, Upstreams: map[string]string{"p1": "m1"}},
+	}}
+	for _, s := range []BindingSnapshot{bad, good, bad} {
+		if err := table.Set(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, ok := table.Get(ModelRef{Namespace: "ns", PublicModel: "dead"}); !ok {
+		t.Error("unroutable snapshot should still be stored")
+	}
+	loads := table.UnroutableLoads()
+	if len(loads) != 1 || loads["ns.dead"] != 2 {
+		t.Errorf("UnroutableLoads = %v", loads)
+	}
+	out := buf.String()
+	if strings.Count(out, "unroutable binding snapshot loaded") != 2 || !strings.Contains(out, "binding_key=ns.dead") {
+		t.Errorf("log output = %q", out)
+	}
+
+	plain := NewBindingTable()
+	if err := plain.Set(bad); err != nil {
+		t.Fatal(err)
+	}
+	if loads := plain.UnroutableLoads(); len(loads) != 0 {
+		t.Errorf("UnroutableLoads without check = %v", loads)
+	}
+}
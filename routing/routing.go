@@ -1,9 +1,11 @@
 package routing
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // ModelRef is a parsed representation of a client-facing model identifier.
@@ -11,6 +13,9 @@ import (
 type ModelRef struct {
 	Namespace   string
 	PublicModel string
+	// ProviderHint is the provider a client asked for with ParseModelRefV2's
+	// three-part form; it is not part of Key.
+	ProviderHint string
 }
 
 func (m ModelRef) Key() string {
@@ -45,6 +50,48 @@ func ParseModelRef(model string, defaultNamespace string) (ModelRef, error) {
 	return ModelRef{Namespace: defaultNamespace, PublicModel: model}, nil
 }
 
+// ParseModelRefV2 is ParseModelRef plus an optional provider hint:
+//
+//	model = public_model                               (defaultNamespace)
+//	      | namespace "." public_model
+//	      | namespace "." provider_hint "." public_model
+//
+// The three-part form applies only when the second segment is accepted by isProvider;
+// otherwise everything after the first dot is the public model, as in ParseModelRef.
+// So "acme.openai.gpt-4o" has hint "openai" if isProvider("openai"), while
+// "acme.gpt-4.1" stays public model "gpt-4.1" because "gpt-4" is not a provider.
+// A public model after a hint may itself contain dots. With a nil isProvider this is
+// exactly ParseModelRef.
+func ParseModelRefV2(model, defaultNamespace string, isProvider func(hint string) bool) (ModelRef, error) {
+	ref, err := ParseModelRef(model, defaultNamespace)
+	if err != nil || isProvider == nil || !strings.Contains(strings.TrimSpace(model), ".") {
+		return ref, err
+	}
+	hint, rest, ok := strings.Cut(ref.PublicModel, ".")
+	hint, rest = strings.TrimSpace(hint), strings.TrimSpace(rest)
+	if !ok || hint == "" || rest == "" || !isProvider(hint) {
+		return ref, nil
+	}
+	ref.ProviderHint, ref.PublicModel = hint, rest
+	return ref, nil
+}
+
+// ParseBindingKey parses a stored bindingKey ("namespace.public_model").
+// The namespace ends at the first dot; public models may themselves contain dots
+// (e.g. "default.gpt-4o.mini" -> namespace "default", public model "gpt-4o.mini").
+// Unlike ParseModelRef there is no default namespace and no trimming: keys must already be canonical.
+func ParseBindingKey(key string) (ModelRef, error) {
+	ns, model, ok := strings.Cut(key, ".")
+	if !ok || ns == "" || model == "" {
+		return ModelRef{}, fmt.Errorf("invalid binding key: %q", key)
+	}
+	ref := ModelRef{Namespace: ns, PublicModel: model}
+	if ref.Key() != key {
+		return ModelRef{}, fmt.Errorf("non-canonical binding key: %q", key)
+	}
+	return ref, nil
+}
+
 func NormalizeModelID(id string) string {
 	id = strings.ToLower(strings.TrimSpace(id))
 	if id == "" {
@@ -63,6 +110,7 @@ const (
 	SelectorExact          SelectorType = "exact"
 	SelectorRegex          SelectorType = "regex"
 	SelectorNormalizeExact SelectorType = "normalize_exact"
+	SelectorGlob           SelectorType = "glob" // "*" matches any run, "?" one character
 )
 
 // ResolveUpstreamModel resolves a single upstream model name for a provider given a selector.
@@ -84,10 +132,14 @@ func ResolveUpstreamModel(selectorType SelectorType, selectorValue string, publi
 			}
 		}
 		return "", fmt.Errorf("no match for %q", v)
-	case SelectorRegex:
-		re, err := regexp.Compile(v)
+	case SelectorRegex, SelectorGlob:
+		kind, expr := "regex", v
+		if selectorType == SelectorGlob {
+			kind, expr = "glob", globToRegex(v)
+		}
+		re, err := regexp.Compile(expr)
 		if err != nil {
-			return "", fmt.Errorf("invalid regex: %w", err)
+			return "", fmt.Errorf("invalid %s: %w", kind, err)
 		}
 		var hits []string
 		for _, m := range providerModels {
@@ -103,9 +155,9 @@ func ResolveUpstreamModel(selectorType SelectorType, selectorValue string, publi
 			return hits[0], nil
 		}
 		if len(hits) == 0 {
-			return "", fmt.Errorf("no regex match for %q", v)
+			return "", fmt.Errorf("no %s match for %q", kind, v)
 		}
-		return "", fmt.Errorf("regex matched multiple models (%d)", len(hits))
+		return "", fmt.Errorf("%s matched multiple models (%d)", kind, len(hits))
 	case SelectorNormalizeExact:
 		want := NormalizeModelID(v)
 		var hit string
@@ -130,6 +182,25 @@ func ResolveUpstreamModel(selectorType SelectorType, selectorValue string, publi
 	}
 }
 
+// globToRegex translates a glob into an anchored regular expression. Only "*" and "?"
+// are special; everything else matches literally.
+func globToRegex(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
 // BindingCandidate represents a single provider group candidate for a bindingKey.
 type BindingCandidate struct {
 	GroupID       uint              `json:"group_id"`
@@ -138,8 +209,10 @@ type BindingCandidate struct {
 	SelectorType  string            `json:"selector_type,omitempty"`
 	SelectorValue string            `json:"selector_value,omitempty"`
 	Status        string            `json:"status,omitempty"`
-	Error         string            `json:"error,omitempty"` // config_error | no_provider
-	Upstreams     map[string]string `json:"upstreams"`       // provider_id -> upstream_model
+	Error         string            `json:"error,omitempty"`       // config_error | no_provider
+	TimeoutMs     int               `json:"timeout_ms,omitempty"`  // 0 = inherit default
+	MaxRetries    int               `json:"max_retries,omitempty"` // 0 = inherit default
+	Upstreams     map[string]string `json:"upstreams"`             // provider_id -> upstream_model
 }
 
 // BindingSnapshot is the DP-consumed snapshot for "(namespace, public_model) -> candidates -> provider -> upstream_model".
@@ -151,3 +224,73 @@ type BindingSnapshot struct {
 	UpdatedAt   int64              `json:"updated_at,omitempty"` // unix seconds
 	Candidates  []BindingCandidate `json:"candidates"`
 }
+
+// Upper bounds for per-candidate failover policy.
+const (
+	MaxCandidateTimeoutMs = 10 * 60 * 1000
+	MaxCandidateRetries   = 10
+)
+
+// EffectiveTimeout returns the candidate's request timeout, or def when it inherits the default.
+func (c BindingCandidate) EffectiveTimeout(def time.Duration) time.Duration {
+	if c.TimeoutMs <= 0 {
+		return def
+	}
+	return time.Duration(c.TimeoutMs) * time.Millisecond
+}
+
+// Candidate error codes carried in BindingCandidate.Error.
+const (
+	CandidateErrorConfig     = "config_error"
+	CandidateErrorNoProvider = "no_provider"
+)
+
+func (c BindingCandidate) Validate() error {
+	if c.Weight < 0 {
+		return errors.New("weight must be >= 0")
+	}
+	if c.TimeoutMs < 0 || c.TimeoutMs > MaxCandidateTimeoutMs {
+		return fmt.Errorf("timeout_ms must be between 0 and %d", MaxCandidateTimeoutMs)
+	}
+	if c.MaxRetries < 0 || c.MaxRetries > MaxCandidateRetries {
+		return fmt.Errorf("max_retries must be between 0 and %d", MaxCandidateRetries)
+	}
+	switch SelectorType(c.SelectorType) {
+	case "", SelectorExact, SelectorRegex, SelectorNormalizeExact, SelectorGlob:
+	default:
+		return fmt.Errorf("unsupported selector type: %q", c.SelectorType)
+	}
+	switch c.Error {
+	case "":
+		if len(c.Upstreams) == 0 {
+			return errors.New("upstreams required")
+		}
+	case CandidateErrorConfig, CandidateErrorNoProvider:
+	default:
+		return fmt.Errorf("unknown candidate error: %q", c.Error)
+	}
+	for providerID, upstream := range c.Upstreams {
+		if strings.TrimSpace(providerID) == "" || strings.TrimSpace(upstream) == "" {
+			return fmt.Errorf("invalid upstream %q -> %q", providerID, upstream)
+		}
+	}
+	return nil
+}
+
+func (s BindingSnapshot) Validate() error {
+	if strings.TrimSpace(s.Namespace) == "" {
+		return errors.New("namespace required")
+	}
+	if strings.TrimSpace(s.PublicModel) == "" {
+		return errors.New("public_model required")
+	}
+	if s.UpdatedAt < 0 {
+		return errors.New("updated_at must be >= 0")
+	}
+	for i, c := range s.Candidates {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("candidate %d (group %d): %w", i, c.GroupID, err)
+		}
+	}
+	return nil
+}
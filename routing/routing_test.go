@@ -0,0 +1,90 @@
+package routing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseModelRefV2(t *testing.T) {
+	providers := map[string]bool{"openai": true, "anthropic": true}
+	isProvider := func(hint string) bool { return providers[hint] }
+
+	tests := []struct {
+		model string
+		want  ModelRef
+	}{
+		{"gpt-4o", ModelRef{Namespace: "default", PublicModel: "gpt-4o"}},
+		{"acme.gpt4", ModelRef{Namespace: "acme", PublicModel: "gpt4"}},
+		{"acme.openai.gpt4", ModelRef{Namespace: "acme", PublicModel: "gpt4", ProviderHint: "openai"}},
+		{" acme . anthropic . claude-3.5-sonnet ", ModelRef{Namespace: "acme", PublicModel: "claude-3.5-sonnet", ProviderHint: "anthropic"}},
+		// Dotted public models without a recognized hint keep the two-part meaning.
+		{"acme.gpt-4.1", ModelRef{Namespace: "acme", PublicModel: "gpt-4.1"}},
+		{"acme.gpt-4o.mini", ModelRef{Namespace: "acme", PublicModel: "gpt-4o.mini"}},
+		// Two segments never carry a hint: "openai" is the namespace here.
+		{"openai.gpt4", ModelRef{Namespace: "openai", PublicModel: "gpt4"}},
+		// A hint needs a public model after it.
+		{"acme.openai.", ModelRef{Namespace: "acme", PublicModel: "openai."}},
+	}
+	for _, tt := range tests {
+		got, err := ParseModelRefV2(tt.model, "default", isProvider)
+		if err != nil {
+			t.Errorf("ParseModelRefV2(%q): %v", tt.model, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseModelRefV2(%q) = %+v, want %+v", tt.model, got, tt.want)
+		}
+		if got.Key() != tt.want.Namespace+"."+tt.want.PublicModel {
+			t.Errorf("Key(%q) = %q, hint must not be part of the key", tt.model, got.Key())
+		}
+	}
+
+	for _, bad := range []string{"", ".gpt4", "acme."} {
+		if _, err := ParseModelRefV2(bad, "default", isProvider); err == nil {
+			t.Errorf("ParseModelRefV2(%q): expected error", bad)
+		}
+	}
+
+	v1, _ := ParseModelRef("acme.openai.gpt4", "default")
+	if v2, _ := ParseModelRefV2("acme.openai.gpt4", "default", nil); v2 != v1 {
+		t.Errorf("nil isProvider: got %+v, want ParseModelRef result %+v", v2, v1)
+	}
+}
+
+func TestResolveUpstreamModelGlob(t *testing.T) {
+	models := []string{"gpt-4o-2024-05-13", "gpt-4o-mini-2024-07-18", " gpt-4.1 ", "claude-3.5-sonnet", ""}
+
+	tests := []struct {
+		glob    string
+		want    string
+		wantErr string
+	}{
+		{"gpt-4o-2024-*", "gpt-4o-2024-05-13", ""},
+		{"gpt-4o-mini-*", "gpt-4o-mini-2024-07-18", ""},
+		{"gpt-4?1", "gpt-4.1", ""},
+		{"claude-3.5-*", "claude-3.5-sonnet", ""},
+		{"claude-3x5-*", "", "no glob match"}, // "." is literal
+		{"gemini-*", "", "no glob match"},
+		{"gpt-4o", "", "no glob match"}, // anchored: no implicit trailing "*"
+		{"gpt-4o-*", "", "glob matched multiple models (2)"},
+		{"*", "", "glob matched multiple models (4)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.glob, func(t *testing.T) {
+			got, err := ResolveUpstreamModel(SelectorGlob, tt.glob, "ignored", models)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveUpstreamModel = (%q, %v), want error %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ResolveUpstreamModel = (%q, %v), want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	if err := (BindingCandidate{SelectorType: string(SelectorGlob), Upstreams: map[string]string{"p1": "m1"}}).Validate(); err != nil {
+		t.Errorf("glob candidate should validate: %v", err)
+	}
+}
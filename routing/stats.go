@@ -0,0 +1,92 @@
+package routing
+
+import (
+	"sort"
+	"strings"
+)
+
+// StatsCounts aggregates a group of binding snapshots.
+type StatsCounts struct {
+	Bindings        int            `json:"bindings"`
+	ErrorBindings   int            `json:"error_bindings"` // snapshot status is error
+	Candidates      int            `json:"candidates"`
+	ErrorCandidates int            `json:"error_candidates"`            // candidate status is error or Error is set
+	CandidateErrors map[string]int `json:"candidate_errors"`            // by BindingCandidate.Error code
+	OldestUpdatedAt int64          `json:"oldest_updated_at,omitempty"` // unix seconds; snapshots without UpdatedAt are ignored
+}
+
+// NamespaceStats is StatsCounts for a single namespace.
+type NamespaceStats struct {
+	Namespace string `json:"namespace"`
+	StatsCounts
+}
+
+// RoutingStats is a per-namespace summary of binding snapshots for admin endpoints.
+type RoutingStats struct {
+	Namespaces []NamespaceStats `json:"namespaces"` // sorted by namespace
+	Total      StatsCounts      `json:"total"`
+}
+
+func newStatsCounts() StatsCounts {
+	return StatsCounts{CandidateErrors: map[string]int{
+		CandidateErrorConfig:     0,
+		CandidateErrorNoProvider: 0,
+	}}
+}
+
+func (c *StatsCounts) add(snap BindingSnapshot) {
+	c.Bindings++
+	if snap.Status == StatusError {
+		c.ErrorBindings++
+	}
+	if snap.UpdatedAt > 0 && (c.OldestUpdatedAt == 0 || snap.UpdatedAt < c.OldestUpdatedAt) {
+		c.OldestUpdatedAt = snap.UpdatedAt
+	}
+	c.Candidates += len(snap.Candidates)
+	for _, cand := range snap.Candidates {
+		if cand.Status == StatusError || cand.Error != "" {
+			c.ErrorCandidates++
+		}
+		if cand.Error != "" {
+			c.CandidateErrors[cand.Error]++
+		}
+	}
+}
+
+// Stats computes per-namespace and global aggregates over snapshots. Snapshots without
+// a valid bindingKey are ignored.
+func Stats(snapshots []BindingSnapshot) RoutingStats {
+	byNamespace := make(map[string]*StatsCounts)
+	total := newStatsCounts()
+	for _, snap := range snapshots {
+		if (ModelRef{Namespace: snap.Namespace, PublicModel: snap.PublicModel}).Key() == "" {
+			continue
+		}
+		ns := strings.TrimSpace(snap.Namespace)
+		counts, ok := byNamespace[ns]
+		if !ok {
+			c := newStatsCounts()
+			counts = &c
+			byNamespace[ns] = counts
+		}
+		counts.add(snap)
+		total.add(snap)
+	}
+	out := RoutingStats{Namespaces: make([]NamespaceStats, 0, len(byNamespace)), Total: total}
+	for ns, counts := range byNamespace {
+		out.Namespaces = append(out.Namespaces, NamespaceStats{Namespace: ns, StatsCounts: *counts})
+	}
+	sort.Slice(out.Namespaces, func(i, j int) bool { return out.Namespaces[i].Namespace < out.Namespaces[j].Namespace })
+	return out
+}
+
+// Stats returns Stats over the snapshots currently in the table.
+func (t *BindingTable) Stats() RoutingStats {
+	t.mu.RLock()
+	snapshots := make([]BindingSnapshot, 0, len(t.snapshots))
+	for _, snap := range t.snapshots {
+		snapshots = append(snapshots, snap)
+	}
+	t.mu.RUnlock()
+	return Stats(snapshots)
+}
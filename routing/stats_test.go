@@ -0,0 +1,66 @@
+package routing
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestStats_Golden(t *testing.T) {
+	snapshots := []BindingSnapshot{
+		{Namespace: "prod", PublicModel: "gpt-4o", UpdatedAt: 1734464000, Candidates: []BindingCandidate{
+			{GroupID: 1, Status: StatusActive, Upstreams: map[string]string{"1": "gpt-4o"}},
+			{GroupID: 2, Status: StatusError, Error: CandidateErrorNoProvider, Upstreams: map[string]string{}},
+		}},
+		{Namespace: "prod", PublicModel: "claude", Status: StatusError, UpdatedAt: 1734460000, Candidates: []BindingCandidate{
+			{GroupID: 3, Status: StatusError, Error: CandidateErrorConfig, Upstreams: map[string]string{}},
+		}},
+		{Namespace: "dev", PublicModel: "gpt-4o", Candidates: []BindingCandidate{
+			{GroupID: 4, Status: StatusActive, Upstreams: map[string]string{"2": "gpt-4o"}},
+		}},
+		{Namespace: "", PublicModel: "ignored"},
+	}
+
+	table := NewBindingTable()
+	for _, snap := range snapshots[:3] {
+		if err := table.Set(snap); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats := Stats(snapshots)
+	fromTable, _ := json.Marshal(table.Stats())
+	if direct, _ := json.Marshal(stats); !bytes.Equal(fromTable, direct) {
+		t.Fatalf("BindingTable.Stats differs from Stats:\n%s\n%s", fromTable, direct)
+	}
+
+	got, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	got = append(got, '\n')
+	const golden = "testdata/routing_stats.json"
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("stats differ from %s:\n%s", golden, got)
+	}
+}
+
+func TestStats_Empty(t *testing.T) {
+	got, err := json.Marshal(Stats(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"namespaces":[],"total":{"bindings":0,"error_bindings":0,"candidates":0,"error_candidates":0,"candidate_errors":{"config_error":0,"no_provider":0}}}`
+	if string(got) != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
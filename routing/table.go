@@ -0,0 +1,141 @@
+package routing
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Freshness classifies a snapshot's age at lookup time.
+type Freshness string
+
+const (
+	Fresh   Freshness = "fresh"
+	Stale   Freshness = "stale"
+	Unknown Freshness = "unknown" // UpdatedAt not set
+)
+
+// BindingTable holds the DP's current snapshots keyed by bindingKey.
+// It is safe for concurrent use.
+type BindingTable struct {
+	mu          sync.RWMutex
+	snapshots   map[string]BindingSnapshot
+	staleServes map[string]uint64
+	unroutable  map[string]uint64
+
+	checkRoutable bool
+	logger        *slog.Logger
+}
+
+// TableOption configures a BindingTable.
+type TableOption func(*BindingTable)
+
+// WithRoutableCheck runs ValidateRoutable on every Set. Unroutable snapshots are still
+// stored (DP keeps the latest CP state) but logged as warnings on l and counted per key
+// (see UnroutableLoads). A nil l falls back to slog.Default.
+func WithRoutableCheck(l *slog.Logger) TableOption {
+	return func(t *BindingTable) {
+		if l == nil {
+			l = slog.Default()
+		}
+		t.checkRoutable = true
+		t.logger = l
+	}
+}
+
+func NewBindingTable(opts ...TableOption) *BindingTable {
+	t := &BindingTable{
+		snapshots:   make(map[string]BindingSnapshot),
+		staleServes: make(map[string]uint64),
+		unroutable:  make(map[string]uint64),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Set stores snap under its bindingKey, replacing any previous snapshot.
+func (t *BindingTable) Set(snap BindingSnapshot) error {
+	key := ModelRef{Namespace: snap.Namespace, PublicModel: snap.PublicModel}.Key()
+	if key == "" {
+		return errors.New("namespace and public_model required")
+	}
+	var routeErr error
+	if t.checkRoutable {
+		routeErr = ValidateRoutable(snap)
+	}
+	t.mu.Lock()
+	t.snapshots[key] = snap
+	if routeErr != nil {
+		t.unroutable[key]++
+	}
+	t.mu.Unlock()
+	if routeErr != nil {
+		t.logger.Warn("unroutable binding snapshot loaded", "binding_key", key, "err", routeErr)
+	}
+	return nil
+}
+
+// Get returns the snapshot for ref regardless of its age.
+func (t *BindingTable) Get(ref ModelRef) (BindingSnapshot, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	snap, ok := t.snapshots[ref.Key()]
+	return snap, ok
+}
+
+// GetFresh is Get plus a freshness verdict: a snapshot is Stale once it is older than
+// maxAge (exactly maxAge is still Fresh) and Unknown when UpdatedAt is zero. Stale
+// snapshots are still returned so callers can keep routing; each stale serve is counted
+// per key (see StaleServes).
+func (t *BindingTable) GetFresh(ref ModelRef, now time.Time, maxAge time.Duration) (BindingSnapshot, Freshness, bool) {
+	key := ref.Key()
+	t.mu.RLock()
+	snap, ok := t.snapshots[key]
+	t.mu.RUnlock()
+	if !ok {
+		return BindingSnapshot{}, Unknown, false
+	}
+	if snap.UpdatedAt == 0 {
+		return snap, Unknown, true
+	}
+	if now.Sub(time.Unix(snap.UpdatedAt, 0)) <= maxAge {
+		return snap, Fresh, true
+	}
+	t.mu.Lock()
+	t.staleServes[key]++
+	t.mu.Unlock()
+	return snap, Stale, true
+}
+
+// StaleServes returns how many times GetFresh served a stale snapshot, per bindingKey.
+func (t *BindingTable) StaleServes() map[string]uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make(map[string]uint64, len(t.staleServes))
+	for k, v := range t.staleServes {
+		out[k] = v
+	}
+	return out
+}
+
+// UnroutableLoads returns how many times Set stored a snapshot that failed
+// ValidateRoutable, per bindingKey. It stays empty without WithRoutableCheck.
+func (t *BindingTable) UnroutableLoads() map[string]uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make(map[string]uint64, len(t.unroutable))
+	for k, v := range t.unroutable {
+		out[k] = v
+	}
+	return out
+}
+
+// Len returns the number of snapshots in the table.
+func (t *BindingTable) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.snapshots)
+}
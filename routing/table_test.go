@@ -0,0 +1,61 @@
+package routing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBindingTableGetFresh(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	maxAge := 30 * time.Second
+
+	table := NewBindingTable()
+	snaps := []BindingSnapshot{
+		{Namespace: "ns", PublicModel: "fresh", UpdatedAt: now.Unix() - 10},
+		{Namespace: "ns", PublicModel: "edge", UpdatedAt: now.Unix() - 30},
+		{Namespace: "ns", PublicModel: "stale", UpdatedAt: now.Unix() - 31},
+		{Namespace: "ns", PublicModel: "undated"},
+	}
+	for _, s := range snaps {
+		if err := table.Set(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		model string
+		want  Freshness
+		found bool
+	}{
+		{"fresh", Fresh, true},
+		{"edge", Fresh, true},
+		{"stale", Stale, true},
+		{"undated", Unknown, true},
+		{"missing", Unknown, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			ref := ModelRef{Namespace: "ns", PublicModel: tt.model}
+			snap, got, ok := table.GetFresh(ref, now, maxAge)
+			if ok != tt.found || got != tt.want {
+				t.Fatalf("GetFresh = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.found)
+			}
+			if ok && snap.PublicModel != tt.model {
+				t.Errorf("wrong snapshot: %+v", snap)
+			}
+		})
+	}
+
+	table.GetFresh(ModelRef{Namespace: "ns", PublicModel: "stale"}, now, maxAge)
+	serves := table.StaleServes()
+	if len(serves) != 1 || serves["ns.stale"] != 2 {
+		t.Errorf("StaleServes = %v", serves)
+	}
+
+	if _, ok := table.Get(ModelRef{Namespace: "ns", PublicModel: "stale"}); !ok {
+		t.Error("Get should ignore age")
+	}
+	if err := table.Set(BindingSnapshot{Namespace: "ns"}); err == nil {
+		t.Error("expected error for snapshot without public_model")
+	}
+}
@@ -0,0 +1,62 @@
+package routing
+
+import (
+	"sort"
+	"strings"
+)
+
+// UsageEntry records one use of a provider by a binding candidate.
+type UsageEntry struct {
+	BindingKey    string `json:"binding_key"`
+	GroupID       uint   `json:"group_id"`
+	UpstreamModel string `json:"upstream_model"`
+	Status        string `json:"status,omitempty"`
+}
+
+// ProviderUsage indexes snapshots by provider id: for each provider, which binding keys
+// and groups route to it and under which upstream model. Entries are sorted by binding
+// key, then group id, then upstream model.
+func ProviderUsage(snapshots []BindingSnapshot) map[string][]UsageEntry {
+	usage := make(map[string][]UsageEntry)
+	for _, snap := range snapshots {
+		key := ModelRef{Namespace: snap.Namespace, PublicModel: snap.PublicModel}.Key()
+		if key == "" {
+			continue
+		}
+		for _, c := range snap.Candidates {
+			for providerID, upstream := range c.Upstreams {
+				usage[providerID] = append(usage[providerID], UsageEntry{
+					BindingKey:    key,
+					GroupID:       c.GroupID,
+					UpstreamModel: upstream,
+					Status:        c.Status,
+				})
+			}
+		}
+	}
+	for _, entries := range usage {
+		sort.Slice(entries, func(i, j int) bool {
+			a, b := entries[i], entries[j]
+			if a.BindingKey != b.BindingKey {
+				return a.BindingKey < b.BindingKey
+			}
+			if a.GroupID != b.GroupID {
+				return a.GroupID < b.GroupID
+			}
+			return a.UpstreamModel < b.UpstreamModel
+		})
+	}
+	return usage
+}
+
+// NamespaceProviderUsage is ProviderUsage restricted to snapshots of namespace.
+func NamespaceProviderUsage(snapshots []BindingSnapshot, namespace string) map[string][]UsageEntry {
+	namespace = strings.TrimSpace(namespace)
+	filtered := make([]BindingSnapshot, 0, len(snapshots))
+	for _, snap := range snapshots {
+		if strings.TrimSpace(snap.Namespace) == namespace {
+			filtered = append(filtered, snap)
+		}
+	}
+	return ProviderUsage(filtered)
+}
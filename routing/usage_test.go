@@ -0,0 +1,48 @@
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func usageSnapshots() []BindingSnapshot {
+	return []BindingSnapshot{
+		{Namespace: "prod", PublicModel: "gpt-4o", Candidates: []BindingCandidate{
+			{GroupID: 2, Status: StatusActive, Upstreams: map[string]string{"p-openai": "gpt-4o", "p-azure": "gpt-4o-2024"}},
+			{GroupID: 1, Status: StatusActive, Upstreams: map[string]string{"p-openai": "gpt-4o-mini"}},
+		}},
+		{Namespace: "dev", PublicModel: "gpt-4o", Candidates: []BindingCandidate{
+			{GroupID: 3, Status: StatusError, Error: CandidateErrorNoProvider},
+			{GroupID: 4, Status: StatusActive, Upstreams: map[string]string{"p-azure": "gpt-4o"}},
+		}},
+		{Namespace: "prod", PublicModel: "claude", Candidates: []BindingCandidate{
+			{GroupID: 1, Status: StatusActive, Upstreams: map[string]string{"p-anthropic": "claude-sonnet"}},
+		}},
+	}
+}
+
+func TestProviderUsage(t *testing.T) {
+	got := ProviderUsage(usageSnapshots())
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"p-anthropic":[{"binding_key":"prod.claude","group_id":1,"upstream_model":"claude-sonnet","status":"active"}],` +
+		`"p-azure":[{"binding_key":"dev.gpt-4o","group_id":4,"upstream_model":"gpt-4o","status":"active"},` +
+		`{"binding_key":"prod.gpt-4o","group_id":2,"upstream_model":"gpt-4o-2024","status":"active"}],` +
+		`"p-openai":[{"binding_key":"prod.gpt-4o","group_id":1,"upstream_model":"gpt-4o-mini","status":"active"},` +
+		`{"binding_key":"prod.gpt-4o","group_id":2,"upstream_model":"gpt-4o","status":"active"}]}`
+	if string(b) != want {
+		t.Errorf("got  %s\nwant %s", b, want)
+	}
+}
+
+func TestNamespaceProviderUsage(t *testing.T) {
+	got := NamespaceProviderUsage(usageSnapshots(), "dev")
+	if len(got) != 1 || len(got["p-azure"]) != 1 || got["p-azure"][0].BindingKey != "dev.gpt-4o" {
+		t.Errorf("unexpected dev usage: %+v", got)
+	}
+	if got := NamespaceProviderUsage(usageSnapshots(), "missing"); len(got) != 0 {
+		t.Errorf("expected no usage, got %+v", got)
+	}
+}
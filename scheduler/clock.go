@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock supplies time to the scheduler's execution wrapper. Tests inject a fake
+// to simulate wall-clock steps.
+type Clock interface {
+	// Now returns the wall-clock time.
+	Now() time.Time
+	// Monotonic returns elapsed time since an arbitrary fixed origin; it never
+	// goes backwards when the wall clock is stepped.
+	Monotonic() time.Duration
+}
+
+type systemClock struct {
+	origin time.Time
+}
+
+func (c systemClock) Now() time.Time { return time.Now() }
+
+func (c systemClock) Monotonic() time.Duration { return time.Since(c.origin) }
+
+// WithClock replaces the system clock.
+func WithClock(clock Clock) Option {
+	return func(s *Scheduler) {
+		if clock != nil {
+			s.clock = clock
+		}
+	}
+}
+
+// WithClockGuard sets the minimum monotonic time between two runs of an @every job.
+// A trigger arriving sooner (e.g. cron re-firing after NTP stepped the wall clock
+// back) is skipped. The default is half the job's interval; 0 keeps the default.
+func WithClockGuard(window time.Duration) Option {
+	return func(s *Scheduler) {
+		if window > 0 {
+			s.clockGuard = window
+		}
+	}
+}
+
+// clockJumpTolerance is how far wall and monotonic elapsed time may disagree before
+// a jump is reported.
+const clockJumpTolerance = time.Second
+
+// fireClock records the last fire of an interval job.
+type fireClock struct {
+	mu    sync.Mutex
+	fired bool
+	wall  time.Time
+	mono  time.Duration
+}
+
+// admitInterval decides whether an @every job may fire now and reports wall-clock
+// jumps observed since its previous fire.
+func (s *Scheduler) admitInterval(job *jobEntry) bool {
+	wall, mono := s.clock.Now(), s.clock.Monotonic()
+
+	job.last.mu.Lock()
+	defer job.last.mu.Unlock()
+
+	if job.last.fired {
+		elapsed := mono - job.last.mono
+		skew := wall.Sub(job.last.wall) - elapsed
+		switch {
+		case skew < -clockJumpTolerance:
+			job.log().Warn("wall clock jumped backward", "delta", -skew)
+		case skew > clockJumpTolerance:
+			job.log().Warn("wall clock jumped forward", "delta", skew)
+		}
+
+		guard := s.clockGuard
+		if guard == 0 {
+			guard = job.interval / 2
+		}
+		if elapsed < guard {
+			s.jobSkipped(job, SkipClockGuard, "since_last", elapsed)
+			return false
+		}
+	}
+
+	job.last.fired = true
+	job.last.wall = wall
+	job.last.mono = mono
+	return true
+}
@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	wall time.Time
+	mono time.Duration
+}
+
+func (c *fakeClock) Now() time.Time           { return c.wall }
+func (c *fakeClock) Monotonic() time.Duration { return c.mono }
+
+// advance moves real (monotonic) time by d and the wall clock by d+step.
+func (c *fakeClock) advance(d, step time.Duration) {
+	c.mono += d
+	c.wall = c.wall.Add(d + step)
+}
+
+func newClockTestScheduler(t *testing.T, opts ...Option) (*Scheduler, *fakeClock, *int, *bytes.Buffer) {
+	t.Helper()
+	clock := &fakeClock{wall: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	s := New(append([]Option{WithClock(clock), WithLogger(logger)}, opts...)...)
+
+	runs := new(int)
+	if err := s.Every("tick", time.Minute, func(ctx context.Context) { *runs++ }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	return s, clock, runs, &logs
+}
+
+func TestSchedulerClockBackwardStep(t *testing.T) {
+	s, clock, runs, logs := newClockTestScheduler(t)
+	job := s.jobs["tick"]
+
+	s.execute(job, false)
+	// NTP steps the wall clock back 5s; cron sees the wall time reach the old
+	// schedule again and re-fires after only 5s of real time.
+	clock.advance(5*time.Second, -5*time.Second)
+	s.execute(job, false)
+	if *runs != 1 {
+		t.Fatalf("runs = %d, want 1 (double fire suppressed)", *runs)
+	}
+	if !strings.Contains(logs.String(), "wall clock jumped backward") || !strings.Contains(logs.String(), "reason=clock_guard") {
+		t.Errorf("expected backward-jump warning and guard skip, logs:\n%s", logs)
+	}
+
+	// The next regular fire is not skipped.
+	clock.advance(time.Minute, 0)
+	s.execute(job, false)
+	if *runs != 2 {
+		t.Errorf("runs = %d, want 2", *runs)
+	}
+}
+
+func TestSchedulerClockForwardStep(t *testing.T) {
+	s, clock, runs, logs := newClockTestScheduler(t)
+	job := s.jobs["tick"]
+
+	s.execute(job, false)
+	clock.advance(time.Minute, time.Hour)
+	s.execute(job, false)
+	clock.advance(time.Minute, 0)
+	s.execute(job, false)
+
+	if *runs != 3 {
+		t.Errorf("runs = %d, want 3 (no interval skipped)", *runs)
+	}
+	if !strings.Contains(logs.String(), "wall clock jumped forward") {
+		t.Errorf("expected forward-jump warning, logs:\n%s", logs)
+	}
+}
+
+func TestSchedulerClockGuardWindow(t *testing.T) {
+	s, clock, runs, _ := newClockTestScheduler(t, WithClockGuard(50*time.Second))
+	job := s.jobs["tick"]
+
+	s.execute(job, false)
+	clock.advance(40*time.Second, 0)
+	s.execute(job, false)
+	clock.advance(10*time.Second, 0)
+	s.execute(job, false)
+
+	if *runs != 2 {
+		t.Errorf("runs = %d, want 2", *runs)
+	}
+}
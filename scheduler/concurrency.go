@@ -0,0 +1,79 @@
+package scheduler
+
+import "time"
+
+// WithMaxConcurrent bounds how many job functions run at once across the scheduler.
+// A run that finds all n slots taken is skipped with SkipConcurrencyLimit and a
+// warning, unless WithConcurrencyWait lets it wait for a slot. n <= 0 means no limit.
+func WithMaxConcurrent(n int) Option {
+	return func(s *Scheduler) {
+		s.slots = nil
+		if n > 0 {
+			s.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithMaxConcurrentJobs is WithMaxConcurrent where a blocked run waits for a slot
+// instead of being skipped, however long it takes; it gives up with SkipStopped once
+// the scheduler stops. Jobs whose overlap policy is OverlapSkip (see WithSkipIfRunning)
+// are skipped with SkipConcurrencyLimit instead of waiting.
+func WithMaxConcurrentJobs(n int) Option {
+	return func(s *Scheduler) {
+		WithMaxConcurrent(n)(s)
+		s.slotWait = waitForSlot
+	}
+}
+
+// waitForSlot is the slotWait set by WithMaxConcurrentJobs: wait until a slot frees up or Stop.
+const waitForSlot time.Duration = -1
+
+// WithConcurrencyWait makes a run blocked by WithMaxConcurrent wait up to d for a
+// free slot before it is skipped. The wait ends early, with SkipStopped, on Stop.
+func WithConcurrencyWait(d time.Duration) Option {
+	return func(s *Scheduler) {
+		s.slotWait = d
+	}
+}
+
+// acquireSlot claims a concurrency slot for job, returning a release func and true
+// on success. Without WithMaxConcurrent it always succeeds.
+func (s *Scheduler) acquireSlot(job *jobEntry) (func(), bool) {
+	if s.slots == nil {
+		return func() {}, true
+	}
+	release := func() { <-s.slots }
+	select {
+	case s.slots <- struct{}{}:
+		return release, true
+	default:
+	}
+	wait := s.slotWait
+	if wait == waitForSlot && s.overlapPolicy(job) == OverlapSkip {
+		wait = 0
+	}
+	if wait != 0 {
+		var timeout <-chan time.Time
+		if wait > 0 {
+			t := time.NewTimer(wait)
+			defer t.Stop()
+			timeout = t.C
+		}
+		select {
+		case s.slots <- struct{}{}:
+			return release, true
+		case <-timeout:
+		case <-s.jobContext().Done():
+			s.jobSkipped(job, SkipStopped)
+			return nil, false
+		}
+	}
+	job.log().Warn("job concurrency limit reached", "limit", cap(s.slots))
+	s.jobSkipped(job, SkipConcurrencyLimit)
+	return nil, false
+}
+
+// ActiveRuns returns how many job functions are executing right now, across all jobs.
+func (s *Scheduler) ActiveRuns() int {
+	return int(s.active.Load())
+}
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func runOverlapping(t *testing.T, s *Scheduler, jobs int) (maxSeen, ran int32) {
+	t.Helper()
+	var active, peak, runs atomic.Int32
+	for i := 0; i < jobs; i++ {
+		if err := s.Every(fmt.Sprintf("slow-%d", i), time.Hour, func(ctx context.Context) {
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			runs.Add(1)
+			time.Sleep(50 * time.Millisecond)
+			active.Add(-1)
+		}); err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		job := s.jobs[fmt.Sprintf("slow-%d", i)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.execute(job, true)
+		}()
+	}
+	wg.Wait()
+	return peak.Load(), runs.Load()
+}
+
+func TestSchedulerMaxConcurrentSkips(t *testing.T) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))), WithMaxConcurrent(2))
+	peak, runs := runOverlapping(t, s, 6)
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", peak)
+	}
+	if runs < 1 || runs == 6 {
+		t.Errorf("runs = %d, want some runs skipped", runs)
+	}
+}
+
+func TestSchedulerMaxConcurrentWaits(t *testing.T) {
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithMaxConcurrent(2),
+		WithConcurrencyWait(5*time.Second),
+	)
+	peak, runs := runOverlapping(t, s, 6)
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", peak)
+	}
+	if runs != 6 {
+		t.Errorf("runs = %d, want all 6 to run after waiting", runs)
+	}
+}
+
+func TestSchedulerMaxConcurrentJobs(t *testing.T) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))), WithMaxConcurrentJobs(2))
+	peak, runs := runOverlapping(t, s, 6)
+	if peak > 2 || runs != 6 {
+		t.Errorf("peak = %d, runs = %d; want <= 2 and all 6 run after waiting", peak, runs)
+	}
+
+	s = New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))), WithMaxConcurrentJobs(2), WithSkipIfRunning())
+	if _, runs := runOverlapping(t, s, 6); runs == 6 {
+		t.Error("with the skip policy, runs over the limit should be skipped")
+	}
+}
+
+func TestSchedulerMaxConcurrentJobsAbortsOnStop(t *testing.T) {
+	var skipped atomic.Value
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithMaxConcurrentJobs(1),
+		WithOnJobSkip(func(name string, r SkipReason) {
+			if name == "waiting" {
+				skipped.Store(r)
+			}
+		}),
+	)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if err := s.Every("holder", time.Hour, func(ctx context.Context) {
+		close(started)
+		<-release
+	}); err != nil {
+		t.Fatal(err)
+	}
+	waitingRan := false
+	if err := s.Every("waiting", time.Hour, func(ctx context.Context) { waitingRan = true }); err != nil {
+		t.Fatal(err)
+	}
+	s.Start()
+	holderDone := make(chan struct{})
+	go func() {
+		s.execute(s.jobs["holder"], true)
+		close(holderDone)
+	}()
+	<-started
+	if n := s.ActiveRuns(); n != 1 {
+		t.Errorf("ActiveRuns = %d, want 1", n)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.execute(s.jobs["waiting"], true)
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	stopped := s.Stop()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("blocked run did not abort on Stop")
+	}
+	if waitingRan || skipped.Load() != SkipStopped {
+		t.Errorf("waiting job ran = %v, skip = %v; want skipped with %q", waitingRan, skipped.Load(), SkipStopped)
+	}
+	close(release)
+	<-stopped.Done()
+	<-holderDone
+	if n := s.ActiveRuns(); n != 0 {
+		t.Errorf("ActiveRuns after Stop = %d, want 0", n)
+	}
+}
@@ -0,0 +1,42 @@
+package scheduler
+
+import "time"
+
+// DriftHook is an optional extension of MetricsHook: if the hook passed to
+// WithMetrics implements it, JobDrift is called for every scheduled fire.
+type DriftHook interface {
+	// JobDrift reports how long after its scheduled time a tick reached the scheduler.
+	JobDrift(name string, drift time.Duration)
+}
+
+// WithMaxDrift logs a warning and calls onExceed (if non-nil) whenever a scheduled
+// fire starts more than d after its scheduled time. Execution is not affected.
+func WithMaxDrift(d time.Duration, onExceed func(jobName string, drift time.Duration)) Option {
+	return func(s *Scheduler) {
+		s.maxDrift = d
+		s.onDrift = onExceed
+	}
+}
+
+// recordDrift measures how late the current scheduled fire of job is, relative to
+// the fire time cron recorded for its entry. Manual runs have no scheduled time.
+func (s *Scheduler) recordDrift(job *jobEntry) {
+	s.mu.RLock()
+	id := job.entryID
+	s.mu.RUnlock()
+	scheduled := s.cron.Entry(id).Prev
+	if scheduled.IsZero() {
+		return
+	}
+	drift := s.clock.Now().Sub(scheduled)
+	job.stats.drifted(drift)
+	if h, ok := s.metrics.(DriftHook); ok {
+		h.JobDrift(job.name, drift)
+	}
+	if s.maxDrift > 0 && drift > s.maxDrift {
+		job.log().Warn("job start drifted", "drift", drift, "max", s.maxDrift)
+		if s.onDrift != nil {
+			s.onDrift(job.name, drift)
+		}
+	}
+}
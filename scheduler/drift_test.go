@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lateClock reports wall time shifted forward, as if every start were delayed.
+type lateClock struct {
+	systemClock
+	delay time.Duration
+}
+
+func (c lateClock) Now() time.Time { return time.Now().Add(c.delay) }
+
+func TestSchedulerDrift(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		exceeded []time.Duration
+		logs     syncBuffer
+	)
+	s := New(
+		WithClock(lateClock{systemClock: systemClock{origin: time.Now()}, delay: 3 * time.Second}),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithMaxDrift(2*time.Second, func(name string, drift time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			exceeded = append(exceeded, drift)
+		}),
+	)
+	ran := make(chan struct{}, 1)
+	if err := s.Every("tick", time.Second, func(ctx context.Context) {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.Start()
+	select {
+	case <-ran:
+	case <-time.After(3 * time.Second):
+		t.Fatal("job did not run")
+	}
+	<-s.Stop().Done()
+
+	st, _ := s.Stats("tick")
+	if st.LastDrift < 3*time.Second || st.LastDrift > 3*time.Second+500*time.Millisecond {
+		t.Errorf("LastDrift = %v, want ~3s", st.LastDrift)
+	}
+	if ms := s.Status().Jobs[0].LastDriftMS; ms < 3000 {
+		t.Errorf("status last_drift_ms = %d, want >= 3000", ms)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(exceeded) == 0 || exceeded[0] < 3*time.Second {
+		t.Errorf("onExceed calls = %v", exceeded)
+	}
+	if !strings.Contains(logs.String(), "job start drifted") {
+		t.Errorf("missing drift warning, logs:\n%s", logs.String())
+	}
+}
+
+func TestSchedulerDriftManualRunsIgnored(t *testing.T) {
+	var logs bytes.Buffer
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithMaxDrift(time.Nanosecond, nil),
+	)
+	if err := s.Every("tick", time.Hour, func(ctx context.Context) {}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.execute(s.jobs["tick"], true)
+	s.execute(s.jobs["tick"], false) // never fired by cron: no scheduled time
+	if st, _ := s.Stats("tick"); st.LastDrift != 0 {
+		t.Errorf("LastDrift = %v, want 0", st.LastDrift)
+	}
+	if strings.Contains(logs.String(), "drifted") {
+		t.Errorf("unexpected drift warning:\n%s", logs.String())
+	}
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// expvars holds the counters published by PublishExpvar. A nil *expvars is a no-op.
+type expvars struct {
+	executions *expvar.Map
+	errors     *expvar.Map
+	skips      *expvar.Map
+	lastRun    *expvar.Map
+}
+
+var publishMu sync.Mutex
+
+// WithExpvar publishes the scheduler's per-job counters under prefix when New returns;
+// see PublishExpvar. Options cannot return errors, so a failure (e.g. prefix already
+// taken) is logged at error level and the scheduler runs without expvars; call
+// PublishExpvar directly to handle it.
+func WithExpvar(prefix string) Option {
+	return func(s *Scheduler) {
+		s.expvarPrefix = prefix
+	}
+}
+
+// PublishExpvar publishes per-job counters under prefix in the expvar registry
+// (served at /debug/vars): executions, errors, skips and last_run (unix seconds).
+// Call it once per scheduler; counters survive Start/Stop cycles. It returns an error
+// if this scheduler already publishes or prefix is taken, since expvar names are global.
+func (s *Scheduler) PublishExpvar(prefix string) error {
+	if prefix == "" {
+		return errors.New("expvar prefix required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.vars != nil {
+		return errors.New("scheduler already publishes expvars")
+	}
+
+	publishMu.Lock()
+	defer publishMu.Unlock()
+	if expvar.Get(prefix) != nil {
+		return fmt.Errorf("expvar %q already published", prefix)
+	}
+	v := &expvars{
+		executions: new(expvar.Map).Init(),
+		errors:     new(expvar.Map).Init(),
+		skips:      new(expvar.Map).Init(),
+		lastRun:    new(expvar.Map).Init(),
+	}
+	root := new(expvar.Map).Init()
+	root.Set("executions", v.executions)
+	root.Set("errors", v.errors)
+	root.Set("skips", v.skips)
+	root.Set("last_run", v.lastRun)
+	expvar.Publish(prefix, root)
+	s.vars = v
+	return nil
+}
+
+func (v *expvars) executed(name string, now time.Time) {
+	if v == nil {
+		return
+	}
+	v.executions.Add(name, 1)
+	last := new(expvar.Int)
+	last.Set(now.Unix())
+	v.lastRun.Set(name, last)
+}
+
+func (v *expvars) failed(name string) {
+	if v != nil {
+		v.errors.Add(name, 1)
+	}
+}
+
+func (v *expvars) skipped(name string) {
+	if v != nil {
+		v.skips.Add(name, 1)
+	}
+}
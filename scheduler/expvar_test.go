@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func expvarInt(t *testing.T, prefix, counter, job string) int64 {
+	t.Helper()
+	root, ok := expvar.Get(prefix).(*expvar.Map)
+	if !ok {
+		t.Fatalf("expvar %q not published", prefix)
+	}
+	m, ok := root.Get(counter).(*expvar.Map)
+	if !ok {
+		t.Fatalf("counter %q missing", counter)
+	}
+	v := m.Get(job)
+	if v == nil {
+		return 0
+	}
+	n, err := strconv.ParseInt(v.String(), 10, 64)
+	if err != nil {
+		t.Fatalf("%s/%s/%s = %q: %v", prefix, counter, job, v.String(), err)
+	}
+	return n
+}
+
+func TestSchedulerExpvar(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithClock(&fakeClock{wall: now}),
+		WithSkipIfRunning(),
+	)
+	if err := s.PublishExpvar("scheduler_test_expvar"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PublishExpvar("scheduler_test_expvar_2"); err == nil {
+		t.Error("expected error publishing twice from one scheduler")
+	}
+	if err := New().PublishExpvar("scheduler_test_expvar"); err == nil {
+		t.Error("expected error for reused prefix")
+	}
+
+	if err := s.EveryE("ok", time.Hour, func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.EveryE("bad", time.Hour, func(ctx context.Context) error { return errors.New("nope") }); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		s.execute(s.jobs["ok"], true)
+	}
+	s.execute(s.jobs["bad"], true)
+	s.jobs["ok"].running.Store(true)
+	s.execute(s.jobs["ok"], true)
+	s.jobs["ok"].running.Store(false)
+
+	// Counters survive a Start/Stop cycle.
+	s.Start()
+	<-s.Stop().Done()
+	s.execute(s.jobs["ok"], true)
+
+	const p = "scheduler_test_expvar"
+	if got := expvarInt(t, p, "executions", "ok"); got != 4 {
+		t.Errorf("executions[ok] = %d, want 4", got)
+	}
+	if got := expvarInt(t, p, "errors", "bad"); got != 1 {
+		t.Errorf("errors[bad] = %d, want 1", got)
+	}
+	if got := expvarInt(t, p, "errors", "ok"); got != 0 {
+		t.Errorf("errors[ok] = %d, want 0", got)
+	}
+	if got := expvarInt(t, p, "skips", "ok"); got != 1 {
+		t.Errorf("skips[ok] = %d, want 1", got)
+	}
+	if got := expvarInt(t, p, "last_run", "bad"); got != now.Unix() {
+		t.Errorf("last_run[bad] = %d, want %d", got, now.Unix())
+	}
+}
+
+func TestSchedulerWithExpvar(t *testing.T) {
+	var logs syncBuffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	s := New(WithLogger(logger), WithExpvar("scheduler_test_with_expvar"))
+	if err := s.Every("ok", time.Hour, func(ctx context.Context) {}); err != nil {
+		t.Fatal(err)
+	}
+	s.execute(s.jobs["ok"], true)
+	if got := expvarInt(t, "scheduler_test_with_expvar", "executions", "ok"); got != 1 {
+		t.Errorf("executions[ok] = %d, want 1", got)
+	}
+
+	// A taken prefix is logged; the scheduler still works.
+	other := New(WithLogger(logger), WithExpvar("scheduler_test_with_expvar"))
+	if other.vars != nil || !strings.Contains(logs.String(), "scheduler expvars not published") {
+		t.Errorf("expected a logged publish failure, got %q", logs.String())
+	}
+}
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Interface is the job registration and control surface of Scheduler. Services that
+// register jobs can depend on it and use schedulertest.Fake in unit tests.
+type Interface interface {
+	Every(name string, interval time.Duration, fn func(ctx context.Context), opts ...JobOption) error
+	EveryE(name string, interval time.Duration, fn func(ctx context.Context) error, opts ...JobOption) error
+	Cron(name string, expr string, fn func(ctx context.Context), opts ...JobOption) error
+	CronE(name string, expr string, fn func(ctx context.Context) error, opts ...JobOption) error
+	Remove(name string) bool
+	RunNow(name string) error
+	SetEnabled(name string, enabled bool) bool
+	Jobs() []Job
+	JobByName(name string) (Job, bool)
+	Start()
+	Stop() context.Context
+	Running() bool
+}
+
+var _ Interface = (*Scheduler)(nil)
+
+// JobSettings is the resolved form of a list of JobOptions.
+type JobSettings struct {
+	Tags            []string
+	Protected       bool
+	Timeout         time.Duration
+	Retry           RetryPolicy
+	Overlap         OverlapPolicy // empty unless Overlap was given
+	SingleflightKey string
+	Until           time.Time
+	MaxRuns         int
+}
+
+// ResolveJobOptions applies opts to a zero configuration and returns the result. It lets
+// scheduler doubles such as schedulertest.Fake honour the same options as Scheduler.
+func ResolveJobOptions(opts ...JobOption) JobSettings {
+	var c jobConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return JobSettings{
+		Tags:            append([]string(nil), c.tags...),
+		Protected:       c.protected,
+		Timeout:         c.timeout,
+		Retry:           c.retry,
+		Overlap:         c.overlap,
+		SingleflightKey: c.singleflightKey,
+		Until:           c.until,
+		MaxRuns:         c.maxRuns,
+	}
+}
@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// sleepJitter waits a random delay in [0, jitter) before a scheduled run. It returns
+// false if the run context was canceled meanwhile (e.g. by Stop), so the run is dropped.
+func (s *Scheduler) sleepJitter(job *jobEntry) bool {
+	max := s.jitter
+	if job.cfg.jitter != nil {
+		max = *job.cfg.jitter
+	}
+	if max <= 0 {
+		return true
+	}
+	timer := time.NewTimer(time.Duration(rand.Int64N(int64(max))))
+	defer timer.Stop()
+	select {
+	case <-s.jobContext().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
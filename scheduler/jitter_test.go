@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchedulerJitter(t *testing.T) {
+	s := New(WithJitter(time.Hour))
+	ran := 0
+	if err := s.Every("jittered", time.Hour, func(ctx context.Context) { ran++ }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("prompt", time.Hour, func(ctx context.Context) { ran++ }, Jitter(0)); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	s.Start()
+	done := make(chan struct{})
+	go func() {
+		s.execute(s.jobs["jittered"], false)
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	stopped := s.Stop()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("pending jitter sleep was not aborted by Stop")
+	}
+	select {
+	case <-stopped.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not complete")
+	}
+	if ran != 0 {
+		t.Errorf("job body ran after aborted jitter")
+	}
+
+	s.execute(s.jobs["prompt"], false)
+	if ran != 1 {
+		t.Errorf("per-job Jitter(0) should run immediately, ran = %d", ran)
+	}
+}
+
+func TestSchedulerJitterRecomputed(t *testing.T) {
+	s := New(WithJitter(20 * time.Millisecond))
+	if err := s.Every("j", time.Hour, func(ctx context.Context) {}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 5; i++ {
+		start := time.Now()
+		s.execute(s.jobs["j"], true) // manual runs are not delayed
+		if time.Since(start) > 10*time.Millisecond {
+			t.Fatal("RunNow-style execution should skip jitter")
+		}
+		start = time.Now()
+		if !s.sleepJitter(s.jobs["j"]) {
+			t.Fatal("sleepJitter aborted without cancellation")
+		}
+		seen[time.Since(start).Round(time.Millisecond)] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("jitter should vary between runs, saw %v", seen)
+	}
+}
+
+func TestSchedulerJitterSpreadsAlignedJobs(t *testing.T) {
+	s := New(WithJitter(200 * time.Millisecond))
+	const n = 10
+	var (
+		mu     sync.Mutex
+		starts []time.Time
+	)
+	for i := 0; i < n; i++ {
+		if err := s.Every(fmt.Sprintf("aligned-%d", i), time.Minute, func(ctx context.Context) {
+			mu.Lock()
+			defer mu.Unlock()
+			starts = append(starts, time.Now())
+		}); err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+	}
+
+	// Fire every job on the same tick, as cron would for aligned schedules.
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		job := s.jobs[fmt.Sprintf("aligned-%d", i)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.execute(job, false)
+		}()
+	}
+	wg.Wait()
+
+	if len(starts) != n {
+		t.Fatalf("started %d jobs, want %d", len(starts), n)
+	}
+	first, last := starts[0], starts[0]
+	for _, st := range starts {
+		if st.Before(first) {
+			first = st
+		}
+		if st.After(last) {
+			last = st
+		}
+	}
+	if spread := last.Sub(first); spread < 20*time.Millisecond {
+		t.Errorf("jobs started within %v of each other, want jitter to spread them", spread)
+	}
+}
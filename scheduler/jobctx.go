@@ -0,0 +1,30 @@
+package scheduler
+
+import "context"
+
+type runKey struct{}
+
+// runInfo identifies one job invocation.
+type runInfo struct {
+	name  string
+	runID string
+}
+
+func withRun(ctx context.Context, name, runID string) context.Context {
+	return context.WithValue(ctx, runKey{}, runInfo{name: name, runID: runID})
+}
+
+// JobNameFromContext returns the name of the job whose run ctx belongs to, or "" if
+// ctx was not passed to a job by a Scheduler.
+func JobNameFromContext(ctx context.Context) string {
+	info, _ := ctx.Value(runKey{}).(runInfo)
+	return info.name
+}
+
+// RunIDFromContext returns the unique ID of the job run ctx belongs to, or "" if ctx
+// was not passed to a job by a Scheduler. The scheduler's "job started" and "job
+// finished" logs carry the same ID as run_id.
+func RunIDFromContext(ctx context.Context) string {
+	info, _ := ctx.Value(runKey{}).(runInfo)
+	return info.runID
+}
@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ez-api/foundation/requestid"
+)
+
+func TestSchedulerJobContextRunInfo(t *testing.T) {
+	var logs syncBuffer
+	s := New(WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+	var names, ids []string
+	if err := s.Every("sync", time.Hour, func(ctx context.Context) {
+		names = append(names, JobNameFromContext(ctx))
+		ids = append(ids, RunIDFromContext(ctx))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		s.execute(s.jobs["sync"], true)
+	}
+
+	if len(ids) != 2 {
+		t.Fatalf("runs = %d, want 2", len(ids))
+	}
+	for i, id := range ids {
+		if names[i] != "sync" {
+			t.Errorf("JobNameFromContext = %q, want sync", names[i])
+		}
+		if !requestid.Valid(id) {
+			t.Errorf("RunIDFromContext = %q, want a request-id formatted value", id)
+		}
+		for _, msg := range []string{`msg="job started"`, `msg="job finished"`} {
+			found := false
+			for _, line := range strings.Split(logs.String(), "\n") {
+				found = found || strings.Contains(line, msg) && strings.Contains(line, " job_name=sync ") && strings.Contains(line, " run_id="+id+" ")
+			}
+			if !found {
+				t.Errorf("%s log for run %s missing:\n%s", msg, id, logs.String())
+			}
+		}
+	}
+	if ids[0] == ids[1] {
+		t.Errorf("run IDs repeat: %s", ids[0])
+	}
+	if JobNameFromContext(context.Background()) != "" || RunIDFromContext(context.Background()) != "" {
+		t.Error("accessors should return empty strings outside a job")
+	}
+}
+
+func TestSchedulerPerRunValues(t *testing.T) {
+	type requestIDKey struct{}
+	type tenantKey struct{}
+	base := context.WithValue(context.Background(), tenantKey{}, "acme")
+	s := New(
+		WithBaseContext(base),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithPerRunValues(func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, requestIDKey{}, requestid.New())
+		}),
+	)
+
+	ids := make(chan string, 2)
+	var last context.Context
+	if err := s.Every("sync", time.Hour, func(ctx context.Context) {
+		if ctx.Value(tenantKey{}) != "acme" {
+			t.Error("base context value lost")
+		}
+		id, _ := ctx.Value(requestIDKey{}).(string)
+		ids <- id
+		last = ctx
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s.Start()
+	s.execute(s.jobs["sync"], true)
+	s.execute(s.jobs["sync"], true)
+	first, second := <-ids, <-ids
+	if first == "" || first == second {
+		t.Errorf("per-run IDs = %q, %q; want two different IDs", first, second)
+	}
+
+	<-s.Stop().Done()
+	if last.Err() == nil {
+		t.Error("per-run context should be canceled by Stop")
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import "time"
+
+// Until stops a job at t: the first fire at or after t (by the scheduler clock) is
+// skipped with SkipExpired and the job is removed.
+func Until(t time.Time) JobOption {
+	return func(c *jobConfig) {
+		c.until = t
+	}
+}
+
+// MaxRuns removes a job, with an info log, once n of its runs have completed
+// successfully (see CountFailures). Scheduled and RunNow runs count alike; a run
+// counts once however many Retry attempts it took, and skipped fires never count.
+// Under OverlapAllow, runs already in flight when the limit is reached still finish.
+// n <= 0 means no limit.
+func MaxRuns(n int) JobOption {
+	return func(c *jobConfig) {
+		c.maxRuns = n
+	}
+}
+
+// CountFailures makes failed runs (errors and panics) count towards MaxRuns too.
+func CountFailures(count bool) JobOption {
+	return func(c *jobConfig) {
+		c.countFailures = count
+	}
+}
+
+// remainingRuns returns how many counted runs job has left, or -1 without MaxRuns.
+func remainingRuns(job *jobEntry) int {
+	if job.cfg.maxRuns <= 0 {
+		return -1
+	}
+	return max(job.cfg.maxRuns-int(job.runs.Load()), 0)
+}
+
+// expired reports whether job is past its Until; such fires are skipped and the job removed.
+func (s *Scheduler) expired(job *jobEntry) bool {
+	if job.cfg.until.IsZero() || s.clock.Now().Before(job.cfg.until) {
+		return false
+	}
+	s.jobSkipped(job, SkipExpired)
+	s.retire(job, "until")
+	return true
+}
+
+// exhausted reports whether job has used up its MaxRuns, skipping and removing it if so.
+// It catches fires that were already under way when the last counted run finished.
+func (s *Scheduler) exhausted(job *jobEntry) bool {
+	if job.cfg.maxRuns <= 0 || remainingRuns(job) > 0 {
+		return false
+	}
+	s.jobSkipped(job, SkipExpired)
+	s.retire(job, "max_runs")
+	return true
+}
+
+// countRun counts a finished run against MaxRuns and removes the job on the last one.
+func (s *Scheduler) countRun(job *jobEntry, err error) {
+	if job.cfg.maxRuns <= 0 || (err != nil && !job.cfg.countFailures) {
+		return
+	}
+	if int(job.runs.Add(1)) >= job.cfg.maxRuns {
+		s.retire(job, "max_runs")
+	}
+}
+
+// retire removes job once its Until or MaxRuns limit is reached, unless it was replaced meanwhile.
+func (s *Scheduler) retire(job *jobEntry, limit string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jobs[job.name] != job {
+		return
+	}
+	s.cron.Remove(job.entryID)
+	delete(s.jobs, job.name)
+	job.log().Info("job limit reached, removed", "limit", limit)
+}
@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSchedulerUntil(t *testing.T) {
+	clock := &fakeClock{wall: time.Date(2026, 3, 30, 9, 0, 0, 0, time.UTC)}
+	var skips []SkipReason
+	s := New(WithClock(clock), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithOnJobSkip(func(_ string, r SkipReason) {
+			skips = append(skips, r)
+		}))
+	end := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+	runs := 0
+	if err := s.Cron("campaign", "0 9 * * *", func(ctx context.Context) { runs++ }, Until(end)); err != nil {
+		t.Fatal(err)
+	}
+	if job, _ := s.JobByName("campaign"); !job.Until.Equal(end) || job.RemainingRuns != -1 {
+		t.Errorf("Job = %+v, want Until %v and no run limit", job, end)
+	}
+	if st := s.Status(); st.Jobs[0].Until == nil || !st.Jobs[0].Until.Equal(end) || st.Jobs[0].RemainingRuns != nil {
+		t.Errorf("Status job = %+v", st.Jobs[0])
+	}
+
+	job := s.jobs["campaign"]
+	s.execute(job, false) // Mar 30
+	clock.wall = clock.wall.Add(24 * time.Hour)
+	s.execute(job, false) // Mar 31
+	clock.wall = clock.wall.Add(24 * time.Hour)
+	s.execute(job, false) // Apr 1: past Until
+	if runs != 2 {
+		t.Errorf("runs = %d, want 2", runs)
+	}
+	if len(skips) != 1 || skips[0] != SkipExpired {
+		t.Errorf("skips = %v, want [expired]", skips)
+	}
+	if _, ok := s.JobByName("campaign"); ok {
+		t.Error("job should be removed after Until")
+	}
+}
+
+func TestSchedulerMaxRuns(t *testing.T) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	calls := 0
+	if err := s.CronE("promo", "0 9 * * *", func(ctx context.Context) error {
+		calls++
+		if calls%2 == 1 {
+			return errors.New("transient")
+		}
+		return nil
+	}, MaxRuns(2), Retry(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond})); err != nil {
+		t.Fatal(err)
+	}
+	job := s.jobs["promo"]
+	s.execute(job, false)
+	if j, _ := s.JobByName("promo"); j.RemainingRuns != 1 {
+		t.Errorf("RemainingRuns = %d, want 1 (a retried run counts once)", j.RemainingRuns)
+	}
+	if st := s.Status(); st.Jobs[0].RemainingRuns == nil || *st.Jobs[0].RemainingRuns != 1 {
+		t.Errorf("Status remaining_runs = %v, want 1", st.Jobs[0].RemainingRuns)
+	}
+	s.execute(job, false)
+	if _, ok := s.JobByName("promo"); ok {
+		t.Error("job should be removed after its last run")
+	}
+	s.execute(job, false) // a fire already in flight when the job was removed
+	if calls != 4 {
+		t.Errorf("calls = %d, want 4 (2 runs x 2 attempts)", calls)
+	}
+}
+
+func TestSchedulerMaxRunsCountsSuccesses(t *testing.T) {
+	for _, countFailures := range []bool{false, true} {
+		s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+		fail := true
+		if err := s.CronE("migrate", "0 * * * *", func(ctx context.Context) error {
+			if fail {
+				return errors.New("db locked")
+			}
+			return nil
+		}, MaxRuns(2), CountFailures(countFailures)); err != nil {
+			t.Fatal(err)
+		}
+		job := s.jobs["migrate"]
+		s.execute(job, false)
+		s.execute(job, false)
+
+		_, registered := s.JobByName("migrate")
+		if countFailures {
+			if registered {
+				t.Error("CountFailures(true): two failed runs should use up MaxRuns(2)")
+			}
+			continue
+		}
+		if j, _ := s.JobByName("migrate"); !registered || j.RemainingRuns != 2 {
+			t.Fatalf("failures should not count: registered = %v, job = %+v", registered, j)
+		}
+		fail = false
+		s.execute(job, false)
+		if j, _ := s.JobByName("migrate"); j.RemainingRuns != 1 {
+			t.Errorf("RemainingRuns = %d, want 1", j.RemainingRuns)
+		}
+		s.execute(job, false)
+		if _, ok := s.JobByName("migrate"); ok {
+			t.Error("job should be removed after its second success")
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultLockTTL bounds a distributed lock for jobs with neither a Timeout nor an
+// @every interval.
+const defaultLockTTL = 5 * time.Minute
+
+// Locker is a cross-instance mutual exclusion primitive, typically backed by Redis.
+// TryLock must not block waiting for the lock: it returns ok=false if key is held
+// elsewhere. The lock must expire after ttl even if release is never called.
+type Locker interface {
+	TryLock(ctx context.Context, key string, ttl time.Duration) (release func(), ok bool, err error)
+}
+
+// WithDistributedLock makes every run (scheduled or via RunNow) first acquire a lock
+// keyed on the job name from locker, so only one replica runs a job at a time. Runs
+// that do not get the lock are skipped with SkipLocked. The lock TTL is the job's
+// LockTTL if set, else its Timeout, else its @every interval, else 5 minutes. The lock
+// is released when the run returns, including when the job panics.
+func WithDistributedLock(locker Locker) Option {
+	return func(s *Scheduler) {
+		s.locker = locker
+	}
+}
+
+// WithLocker is an alias for WithDistributedLock.
+func WithLocker(locker Locker) Option {
+	return WithDistributedLock(locker)
+}
+
+// LockTTL overrides how long a run of this job may hold its distributed lock.
+func LockTTL(d time.Duration) JobOption {
+	return func(c *jobConfig) {
+		c.lockTTL = d
+	}
+}
+
+// lockTTL returns how long a run of job may hold its distributed lock.
+func lockTTL(job *jobEntry) time.Duration {
+	switch {
+	case job.cfg.lockTTL > 0:
+		return job.cfg.lockTTL
+	case job.cfg.timeout > 0:
+		return job.cfg.timeout
+	case job.interval > 0:
+		return job.interval
+	default:
+		return defaultLockTTL
+	}
+}
+
+// acquireLock claims job's distributed lock, returning a release func and true on
+// success. Without a Locker it always succeeds.
+func (s *Scheduler) acquireLock(ctx context.Context, job *jobEntry) (func(), bool) {
+	if s.locker == nil {
+		return func() {}, true
+	}
+	release, ok, err := s.locker.TryLock(ctx, job.name, lockTTL(job))
+	if err != nil {
+		job.log().Warn("job lock failed", "err", err)
+		s.jobSkipped(job, SkipLocked)
+		return nil, false
+	}
+	if !ok {
+		s.jobSkipped(job, SkipLocked)
+		return nil, false
+	}
+	if release == nil {
+		release = func() {}
+	}
+	return release, true
+}
+
+// MemoryLocker is an in-process Locker, for tests and single-binary deployments that
+// run several schedulers.
+type MemoryLocker struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	held  map[string]memoryLock
+	token uint64
+}
+
+type memoryLock struct {
+	token   uint64
+	expires time.Time
+}
+
+// NewMemoryLocker returns an empty MemoryLocker.
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{now: time.Now, held: make(map[string]memoryLock)}
+}
+
+// TryLock implements Locker. An expired lock can be taken over; the previous holder's
+// release then does nothing.
+func (l *MemoryLocker) TryLock(_ context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if cur, ok := l.held[key]; ok && now.Before(cur.expires) {
+		return nil, false, nil
+	}
+	l.token++
+	token := l.token
+	l.held[key] = memoryLock{token: token, expires: now.Add(ttl)}
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.held[key].token == token {
+			delete(l.held, key)
+		}
+	}, true, nil
+}
+
+// Held reports whether key is currently locked.
+func (l *MemoryLocker) Held(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cur, ok := l.held[key]
+	return ok && l.now().Before(cur.expires)
+}
@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeLocker struct {
+	mu   sync.Mutex
+	held map[string]time.Duration
+	err  error
+}
+
+func (l *fakeLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return nil, false, l.err
+	}
+	if _, ok := l.held[key]; ok {
+		return nil, false, nil
+	}
+	l.held[key] = ttl
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, key)
+	}, true, nil
+}
+
+func TestSchedulerDistributedLock(t *testing.T) {
+	locker := &fakeLocker{held: map[string]time.Duration{}}
+	newReplica := func() (*Scheduler, *int) {
+		runs := new(int)
+		s := New(
+			WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+			WithDistributedLock(locker),
+		)
+		if err := s.Every("refresh", time.Minute, func(ctx context.Context) {
+			*runs++
+			if ttl := locker.held["refresh"]; ttl != time.Minute {
+				t.Errorf("lock ttl = %v, want interval", ttl)
+			}
+		}); err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+		return s, runs
+	}
+	a, aRuns := newReplica()
+	b, bRuns := newReplica()
+
+	// Replica b holds the lock while a fires.
+	release, _, _ := locker.TryLock(context.Background(), "refresh", time.Minute)
+	a.execute(a.jobs["refresh"], true)
+	if *aRuns != 0 {
+		t.Errorf("a ran while lock was held elsewhere")
+	}
+	if st, _ := a.Stats("refresh"); st.Runs != 0 {
+		t.Errorf("skipped run counted in stats")
+	}
+	release()
+
+	b.execute(b.jobs["refresh"], true)
+	a.execute(a.jobs["refresh"], true)
+	if *aRuns != 1 || *bRuns != 1 {
+		t.Errorf("runs a=%d b=%d, want 1 each", *aRuns, *bRuns)
+	}
+	if len(locker.held) != 0 {
+		t.Errorf("locks not released: %v", locker.held)
+	}
+
+	locker.err = errors.New("redis down")
+	a.execute(a.jobs["refresh"], true)
+	if *aRuns != 1 {
+		t.Errorf("a ran despite lock error")
+	}
+}
+
+func TestLockTTL(t *testing.T) {
+	tests := []struct {
+		job  *jobEntry
+		want time.Duration
+	}{
+		{&jobEntry{cfg: jobConfig{lockTTL: time.Minute, timeout: time.Second}, interval: time.Hour}, time.Minute},
+		{&jobEntry{cfg: jobConfig{timeout: time.Second}, interval: time.Hour}, time.Second},
+		{&jobEntry{interval: time.Hour}, time.Hour},
+		{&jobEntry{}, defaultLockTTL},
+	}
+	for _, tt := range tests {
+		if got := lockTTL(tt.job); got != tt.want {
+			t.Errorf("lockTTL = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestMemoryLocker(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewMemoryLocker()
+	l.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	release, ok, err := l.TryLock(ctx, "cleanup", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("first TryLock = %v, %v", ok, err)
+	}
+	if _, ok, _ := l.TryLock(ctx, "cleanup", time.Minute); ok {
+		t.Fatal("second TryLock should fail while held")
+	}
+
+	now = now.Add(time.Minute)
+	takeover, ok, _ := l.TryLock(ctx, "cleanup", time.Minute)
+	if !ok {
+		t.Fatal("expired lock should be taken over")
+	}
+	release() // stale holder must not free the new owner's lock
+	if !l.Held("cleanup") {
+		t.Fatal("stale release freed the lock")
+	}
+	takeover()
+	if l.Held("cleanup") {
+		t.Fatal("lock still held after release")
+	}
+}
+
+func TestSchedulerLockReleasedOnPanic(t *testing.T) {
+	locker := NewMemoryLocker()
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))), WithLocker(locker))
+	var heldDuringRun bool
+	if err := s.Every("cleanup", time.Hour, func(ctx context.Context) {
+		heldDuringRun = locker.Held("cleanup")
+		panic("boom")
+	}, LockTTL(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	s.execute(s.jobs["cleanup"], true)
+	if !heldDuringRun {
+		t.Error("lock not held during the run")
+	}
+	if locker.Held("cleanup") {
+		t.Error("lock not released after panic")
+	}
+}
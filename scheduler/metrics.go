@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+)
+
+// MetricsHook receives job lifecycle events, e.g. to export Prometheus metrics.
+// Calls are made synchronously from the job goroutine, so implementations must be
+// safe for concurrent use and should not block.
+type MetricsHook interface {
+	// JobStarted is called before the job body runs (scheduled or via RunNow).
+	JobStarted(name string)
+	// JobCompleted is called after the job body returns, including retries. A panic
+	// is reported as a *PanicError.
+	JobCompleted(name string, duration time.Duration, err error)
+	// JobSkipped is called when a run is dropped before the job body executes.
+	JobSkipped(name string, reason SkipReason)
+}
+
+// WithMetrics reports every job's executions and skips to m.
+func WithMetrics(m MetricsHook) Option {
+	return func(s *Scheduler) {
+		if m != nil {
+			s.metrics = m
+		}
+	}
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) JobStarted(string)                         {}
+func (noopMetrics) JobCompleted(string, time.Duration, error) {}
+func (noopMetrics) JobSkipped(string, SkipReason)             {}
+
+// WithOnJobStart sets a callback invoked before every job run.
+// Each call is paired with exactly one WithOnJobFinish call. Skipped runs never
+// start; they are reported to WithOnJobSkip instead.
+func WithOnJobStart(fn func(name string)) Option {
+	return func(s *Scheduler) {
+		s.onJobStart = fn
+	}
+}
+
+// WithOnJobFinish sets a callback invoked after every job run with its duration.
+// recovered is the panic value if the job panicked, or nil.
+func WithOnJobFinish(fn func(name string, dur time.Duration, recovered any)) Option {
+	return func(s *Scheduler) {
+		s.onJobFinish = fn
+	}
+}
+
+// WithOnJobSkip sets a callback invoked when a run is dropped before the job body
+// executes, e.g. by skip-if-running, with the reason.
+func WithOnJobSkip(fn func(name string, reason SkipReason)) Option {
+	return func(s *Scheduler) {
+		s.onJobSkip = fn
+	}
+}
+
+func (s *Scheduler) jobStartHook(name string) {
+	if s.onJobStart != nil {
+		s.onJobStart(name)
+	}
+}
+
+// jobFinishHook reports a finished run; err is the run's error, if any.
+func (s *Scheduler) jobFinishHook(name string, dur time.Duration, err error) {
+	if s.onJobFinish == nil {
+		return
+	}
+	var recovered any
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		recovered = pe.Value
+	}
+	s.onJobFinish(name, dur, recovered)
+}
+
+func (s *Scheduler) jobSkipHook(name string, reason SkipReason) {
+	if s.onJobSkip != nil {
+		s.onJobSkip(name, reason)
+	}
+}
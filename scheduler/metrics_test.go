@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	mu     sync.Mutex
+	events []string
+	errs   map[string]error
+}
+
+func (m *recordingMetrics) JobStarted(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, "start:"+name)
+}
+
+func (m *recordingMetrics) JobCompleted(name string, d time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, "done:"+name)
+	m.errs[name] = err
+}
+
+func (m *recordingMetrics) JobSkipped(name string, reason SkipReason) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, "skip:"+name+":"+string(reason))
+}
+
+func TestSchedulerMetricsHook(t *testing.T) {
+	m := &recordingMetrics{errs: map[string]error{}}
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithSkipIfRunning(),
+		WithMetrics(m),
+	)
+
+	errSync := errors.New("upstream unavailable")
+	if err := s.EveryE("sync", time.Hour, func(ctx context.Context) error { return errSync }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("boom", time.Hour, func(ctx context.Context) { panic("kaput") }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("busy", time.Hour, func(ctx context.Context) {}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	s.execute(s.jobs["sync"], true)
+	s.execute(s.jobs["boom"], true)
+	s.jobs["busy"].running.Store(true)
+	s.execute(s.jobs["busy"], true)
+
+	want := []string{"start:sync", "done:sync", "start:boom", "done:boom", "skip:busy:" + string(SkipStillRunning)}
+	if len(m.events) != len(want) {
+		t.Fatalf("events = %v, want %v", m.events, want)
+	}
+	for i := range want {
+		if m.events[i] != want[i] {
+			t.Errorf("events[%d] = %q, want %q", i, m.events[i], want[i])
+		}
+	}
+	if !errors.Is(m.errs["sync"], errSync) {
+		t.Errorf("sync error = %v, want %v", m.errs["sync"], errSync)
+	}
+	var pe *PanicError
+	if !errors.As(m.errs["boom"], &pe) {
+		t.Errorf("boom error = %v, want *PanicError", m.errs["boom"])
+	}
+}
+
+func TestSchedulerMetricsDefaultNoAlloc(t *testing.T) {
+	s := New(WithMetrics(nil))
+	allocs := testing.AllocsPerRun(100, func() {
+		s.metrics.JobStarted("job")
+		s.metrics.JobCompleted("job", time.Second, nil)
+		s.metrics.JobSkipped("job", SkipStillRunning)
+	})
+	if allocs != 0 {
+		t.Errorf("no-op metrics allocated %v times per run", allocs)
+	}
+}
+
+func TestSchedulerLifecycleHooks(t *testing.T) {
+	type finish struct {
+		name      string
+		recovered any
+	}
+	var (
+		mu       sync.Mutex
+		starts   []string
+		finishes []finish
+		skips    []SkipReason
+	)
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithSkipIfRunning(),
+		WithOnJobStart(func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			starts = append(starts, name)
+		}),
+		WithOnJobFinish(func(name string, dur time.Duration, recovered any) {
+			mu.Lock()
+			defer mu.Unlock()
+			finishes = append(finishes, finish{name, recovered})
+		}),
+		WithOnJobSkip(func(name string, reason SkipReason) {
+			mu.Lock()
+			defer mu.Unlock()
+			skips = append(skips, reason)
+		}),
+	)
+
+	if err := s.EveryE("fail", time.Hour, func(ctx context.Context) error { return errors.New("nope") }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("boom", time.Hour, func(ctx context.Context) { panic("kaput") }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("busy", time.Hour, func(ctx context.Context) {}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	s.execute(s.jobs["fail"], true)
+	s.execute(s.jobs["boom"], true)
+	s.jobs["busy"].running.Store(true)
+	s.execute(s.jobs["busy"], true)
+
+	// The skipped run neither starts nor finishes; it is reported only as a skip.
+	if want := []string{"fail", "boom"}; len(starts) != len(want) || starts[0] != want[0] || starts[1] != want[1] {
+		t.Errorf("starts = %v, want %v", starts, want)
+	}
+	if len(skips) != 1 || skips[0] != SkipStillRunning {
+		t.Errorf("skips = %v, want [%s]", skips, SkipStillRunning)
+	}
+	want := []finish{{"fail", nil}, {"boom", "kaput"}}
+	if len(finishes) != len(want) {
+		t.Fatalf("finishes = %v, want %v", finishes, want)
+	}
+	for i := range want {
+		if finishes[i] != want[i] {
+			t.Errorf("finishes[%d] = %v, want %v", i, finishes[i], want[i])
+		}
+	}
+}
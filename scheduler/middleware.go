@@ -0,0 +1,27 @@
+package scheduler
+
+import "context"
+
+// JobMiddleware wraps a job body, e.g. to open a tracing span around it. It must call
+// next to run the job; the job's error, if any, is still reported by the scheduler.
+type JobMiddleware func(name string, next func(ctx context.Context)) func(ctx context.Context)
+
+// WithJobMiddleware wraps every job run (retries included) in mw. The first middleware
+// is outermost. Middleware runs inside the scheduler's panic recovery and applies to
+// jobs registered at any time, including after Start.
+func WithJobMiddleware(mw ...JobMiddleware) Option {
+	return func(s *Scheduler) {
+		s.middleware = append(s.middleware, mw...)
+	}
+}
+
+// runMiddleware runs job through the middleware chain and returns the job's error.
+func (s *Scheduler) runMiddleware(ctx context.Context, job *jobEntry) error {
+	var err error
+	body := func(ctx context.Context) { err = s.runWithRetry(ctx, job) }
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		body = s.middleware[i](job.name, body)
+	}
+	body(ctx)
+	return err
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSchedulerJobMiddleware(t *testing.T) {
+	type traceKey struct{}
+	var (
+		order   []string
+		elapsed = map[string]time.Duration{}
+		errs    = map[string]error{}
+	)
+	timing := func(name string, next func(ctx context.Context)) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			order = append(order, "timing:before")
+			start := time.Now()
+			defer func() {
+				elapsed[name] = time.Since(start)
+				order = append(order, "timing:after")
+			}()
+			next(ctx)
+		}
+	}
+	tag := func(name string, next func(ctx context.Context)) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			order = append(order, "tag:before")
+			next(context.WithValue(ctx, traceKey{}, "traced"))
+			order = append(order, "tag:after")
+		}
+	}
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithErrorHandler(func(name string, err error) { errs[name] = err }),
+		WithJobMiddleware(timing, tag),
+	)
+	s.Start()
+	defer s.Stop()
+
+	// Registered after Start: middleware still applies.
+	errJob := errors.New("sync failed")
+	if err := s.EveryE("sync", time.Hour, func(ctx context.Context) error {
+		order = append(order, "job:"+ctx.Value(traceKey{}).(string))
+		time.Sleep(10 * time.Millisecond)
+		return errJob
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.execute(s.jobs["sync"], true)
+
+	want := []string{"timing:before", "tag:before", "job:traced", "tag:after", "timing:after"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("order[%d] = %q, want %q", i, order[i], want[i])
+		}
+	}
+	if elapsed["sync"] < 10*time.Millisecond {
+		t.Errorf("elapsed = %v, want >= 10ms", elapsed["sync"])
+	}
+	if !errors.Is(errs["sync"], errJob) {
+		t.Errorf("job error = %v, want it reported through middleware", errs["sync"])
+	}
+
+	// A panic in the job body is still recovered by the scheduler.
+	if err := s.Every("boom", time.Hour, func(ctx context.Context) { panic("kaput") }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.execute(s.jobs["boom"], true)
+	var pe *PanicError
+	if !errors.As(errs["boom"], &pe) {
+		t.Errorf("boom error = %v, want *PanicError", errs["boom"])
+	}
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// onceSchedule fires a single time at at. An overdue schedule (see RunIfPast) fires
+// at the first Next call instead, which cron makes when the entry is added or started.
+type onceSchedule struct {
+	at      time.Time
+	overdue *atomic.Bool
+}
+
+func (o onceSchedule) Next(now time.Time) time.Time {
+	if now.Before(o.at) {
+		return o.at
+	}
+	if o.overdue != nil && o.overdue.CompareAndSwap(true, false) {
+		return now
+	}
+	return time.Time{} // cron never fires an entry with a zero next time
+}
+
+// RunIfPast makes At and After run a job whose time has already passed as soon as
+// the scheduler is running, instead of returning an error.
+func RunIfPast() JobOption {
+	return func(c *jobConfig) {
+		c.runIfPast = true
+	}
+}
+
+// At schedules fn to run once at t, with the same recovery, context and per-job
+// options as recurring jobs. The job is listed in Jobs() until it runs and is removed
+// afterwards. If the scheduler is not running when t arrives, the job does not fire
+// (and stays listed until Remove). It returns an error if t is not in the future,
+// unless the RunIfPast option is given.
+func (s *Scheduler) At(name string, t time.Time, fn func(ctx context.Context), opts ...JobOption) error {
+	job := newJobEntry(name, "@at "+t.In(s.location).Format(time.RFC3339), noError(fn), opts)
+	job.once = true
+	sched := onceSchedule{at: t}
+	if !t.After(s.clock.Now()) {
+		if !job.cfg.runIfPast {
+			return fmt.Errorf("one-shot job %s: time %s is not in the future", name, t.Format(time.RFC3339))
+		}
+		sched.overdue = new(atomic.Bool)
+		sched.overdue.Store(true)
+	}
+	return s.addSchedule(job, sched, s.replaceExisting)
+}
+
+// After schedules fn to run once, d from now; see At.
+func (s *Scheduler) After(name string, d time.Duration, fn func(ctx context.Context), opts ...JobOption) error {
+	return s.At(name, s.clock.Now().Add(d), fn, opts...)
+}
+
+// removeFired unregisters a one-shot job after its run, unless it was replaced meanwhile.
+func (s *Scheduler) removeFired(job *jobEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jobs[job.name] != job {
+		return
+	}
+	s.cron.Remove(job.entryID)
+	delete(s.jobs, job.name)
+	job.log().Debug("one-shot job removed")
+}
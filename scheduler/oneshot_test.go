@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerAfterRunsOnceAndRemoves(t *testing.T) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	var runs atomic.Int32
+	done := make(chan struct{})
+	if err := s.After("once", 200*time.Millisecond, func(ctx context.Context) {
+		if runs.Add(1) == 1 {
+			close(done)
+		}
+	}); err != nil {
+		t.Fatalf("After: %v", err)
+	}
+	if job, ok := s.JobByName("once"); !ok || job.Schedule == "" {
+		t.Fatalf("job not registered: %+v", job)
+	}
+
+	s.Start()
+	defer s.Stop()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("one-shot job did not run")
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := s.JobByName("once"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("one-shot job still registered after running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(300 * time.Millisecond)
+	if n := runs.Load(); n != 1 {
+		t.Errorf("runs = %d, want 1", n)
+	}
+}
+
+func TestSchedulerAtRejectsPast(t *testing.T) {
+	s := New()
+	if err := s.At("late", time.Now().Add(-time.Minute), func(ctx context.Context) {}); err == nil {
+		t.Fatal("expected error for past time")
+	}
+	if err := s.After("now", 0, func(ctx context.Context) {}); err == nil {
+		t.Fatal("expected error for zero delay")
+	}
+	if len(s.Jobs()) != 0 {
+		t.Errorf("rejected jobs were registered: %v", s.Jobs())
+	}
+}
+
+func TestSchedulerAtNotFiredWhenStopped(t *testing.T) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	var runs atomic.Int32
+	if err := s.After("once", 200*time.Millisecond, func(ctx context.Context) { runs.Add(1) }); err != nil {
+		t.Fatalf("After: %v", err)
+	}
+	s.Start()
+	<-s.Stop().Done()
+	time.Sleep(400 * time.Millisecond)
+	if n := runs.Load(); n != 0 {
+		t.Errorf("runs = %d, want 0 after Stop", n)
+	}
+}
+
+func TestSchedulerAtRunIfPast(t *testing.T) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	var runs atomic.Int32
+	fn := func(ctx context.Context) { runs.Add(1) }
+	if err := s.At("overdue", time.Now().Add(-time.Hour), fn, RunIfPast()); err != nil {
+		t.Fatalf("At: %v", err)
+	}
+	if _, ok := s.JobByName("overdue"); !ok {
+		t.Fatal("overdue job not listed before Start")
+	}
+	s.Start()
+	defer s.Stop()
+	if err := s.After("late", -time.Second, fn, RunIfPast()); err != nil {
+		t.Fatalf("After: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runs.Load() < 2 || len(s.Jobs()) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("runs = %d, jobs = %v; want 2 runs and no jobs left", runs.Load(), s.Jobs())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if n := runs.Load(); n != 2 {
+		t.Errorf("runs = %d, want 2", n)
+	}
+}
@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"slices"
+	"time"
+)
+
+// JobOption configures a single job at registration time.
+type JobOption func(*jobConfig)
+
+type jobConfig struct {
+	singleflightKey  string
+	singleflightWait bool
+	tags             []string
+	protected        bool
+	timeout          time.Duration
+	retry            RetryPolicy
+	jitter           *time.Duration
+	overlap          OverlapPolicy
+	runIfPast        bool
+	lockTTL          time.Duration
+	until            time.Time
+	maxRuns          int
+	countFailures    bool
+}
+
+// equal reports whether c and o configure a job identically.
+func (c jobConfig) equal(o jobConfig) bool {
+	sameJitter := (c.jitter == nil) == (o.jitter == nil) && (c.jitter == nil || *c.jitter == *o.jitter)
+	return sameJitter &&
+		slices.Equal(c.tags, o.tags) &&
+		c.singleflightKey == o.singleflightKey &&
+		c.singleflightWait == o.singleflightWait &&
+		c.protected == o.protected &&
+		c.timeout == o.timeout &&
+		c.retry == o.retry &&
+		c.overlap == o.overlap &&
+		c.runIfPast == o.runIfPast &&
+		c.lockTTL == o.lockTTL &&
+		c.until.Equal(o.until) &&
+		c.maxRuns == o.maxRuns &&
+		c.countFailures == o.countFailures
+}
+
+// SkipReason explains why a scheduled execution did not run the job body.
+type SkipReason string
+
+const (
+	// SkipCoalesced means another job sharing the same singleflight key was already running.
+	SkipCoalesced SkipReason = "coalesced"
+	// SkipStillRunning means the previous run of the job had not finished (WithSkipIfRunning).
+	SkipStillRunning SkipReason = "still_running"
+	// SkipClockGuard means an @every job fired again within its clock guard window.
+	SkipClockGuard SkipReason = "clock_guard"
+	// SkipLocked means another instance held the job's distributed lock (WithDistributedLock).
+	SkipLocked SkipReason = "locked"
+	// SkipConcurrencyLimit means the scheduler-wide limit was reached (WithMaxConcurrent).
+	SkipConcurrencyLimit SkipReason = "concurrency_limit"
+	// SkipQueueFull means the worker pool queue was full (WithWorkerPool).
+	SkipQueueFull SkipReason = "queue_full"
+	// SkipStopped means a queued run was dropped because the scheduler stopped.
+	SkipStopped SkipReason = "stopped"
+	// SkipExpired means the job was past its Until or had used up its MaxRuns; it is removed.
+	SkipExpired SkipReason = "expired"
+)
+
+// SingleflightKey coalesces executions of all jobs that share key: while one of them
+// runs, triggers of the others do not run their body. By default such a trigger
+// returns immediately; combine with SingleflightWait to block until the in-flight run finishes.
+func SingleflightKey(key string) JobOption {
+	return func(c *jobConfig) {
+		c.singleflightKey = key
+	}
+}
+
+// SingleflightWait makes a coalesced trigger wait for the in-flight run to finish
+// (sharing its result) instead of returning immediately.
+func SingleflightWait() JobOption {
+	return func(c *jobConfig) {
+		c.singleflightWait = true
+	}
+}
+
+// Tags attaches free-form labels to a job; they are reported in Jobs().
+func Tags(tags ...string) JobOption {
+	return func(c *jobConfig) {
+		c.tags = append([]string(nil), tags...)
+	}
+}
+
+// Protected keeps a job from being removed by Reconcile when it is missing from the desired set.
+func Protected() JobOption {
+	return func(c *jobConfig) {
+		c.protected = true
+	}
+}
+
+// Timeout cancels the context passed to the job d after each run starts and logs a
+// warning when that happens. Jobs must honor ctx; the scheduler does not wait for them
+// (a job ignoring cancellation keeps running, and under WithSkipIfRunning keeps
+// suppressing later ticks until it returns).
+func Timeout(d time.Duration) JobOption {
+	return func(c *jobConfig) {
+		c.timeout = d
+	}
+}
+
+// RetryPolicy retries a failing job run inline with exponential backoff and jitter.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per run, including the first.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles per attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff; 0 means no cap.
+	MaxDelay time.Duration
+}
+
+// Retry re-runs a job whose function returns an error (EveryE, CronE) according to p.
+// Retries stop early when the run context is canceled; Timeout bounds the whole run,
+// retries included.
+func Retry(p RetryPolicy) JobOption {
+	return func(c *jobConfig) {
+		c.retry = p
+	}
+}
+
+// Jitter overrides the scheduler-wide WithJitter for this job; 0 disables jitter.
+func Jitter(max time.Duration) JobOption {
+	return func(c *jobConfig) {
+		c.jitter = &max
+	}
+}
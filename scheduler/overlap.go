@@ -0,0 +1,63 @@
+package scheduler
+
+import "time"
+
+// OverlapPolicy decides what happens when a job fires while its previous run is
+// still in progress.
+type OverlapPolicy string
+
+const (
+	// OverlapAllow runs overlapping invocations concurrently (the default).
+	OverlapAllow OverlapPolicy = "allow"
+	// OverlapSkip drops the new invocation with SkipStillRunning (WithSkipIfRunning).
+	OverlapSkip OverlapPolicy = "skip"
+	// OverlapDelay waits for the previous run to finish, like cron.DelayIfStillRunning.
+	OverlapDelay OverlapPolicy = "delay"
+)
+
+// Overlap sets this job's overlap policy, taking precedence over WithSkipIfRunning.
+func Overlap(p OverlapPolicy) JobOption {
+	return func(c *jobConfig) {
+		c.overlap = p
+	}
+}
+
+// overlapPolicy returns the effective policy for job.
+func (s *Scheduler) overlapPolicy(job *jobEntry) OverlapPolicy {
+	switch {
+	case job.cfg.overlap != "":
+		return job.cfg.overlap
+	case s.skipIfRunning:
+		return OverlapSkip
+	default:
+		return OverlapAllow
+	}
+}
+
+// claimOverlap applies job's overlap policy before a run. It returns false if the
+// run must be skipped; otherwise releaseOverlap must follow the run.
+func (s *Scheduler) claimOverlap(job *jobEntry) bool {
+	switch s.overlapPolicy(job) {
+	case OverlapSkip:
+		if !job.running.CompareAndSwap(false, true) {
+			s.jobSkipped(job, SkipStillRunning)
+			return false
+		}
+	case OverlapDelay:
+		start := time.Now()
+		job.delay.Lock()
+		if waited := time.Since(start); waited > time.Millisecond {
+			job.log().Debug("job delayed by previous run", "waited", waited)
+		}
+	}
+	return true
+}
+
+func (s *Scheduler) releaseOverlap(job *jobEntry) {
+	switch s.overlapPolicy(job) {
+	case OverlapSkip:
+		job.running.Store(false)
+	case OverlapDelay:
+		job.delay.Unlock()
+	}
+}
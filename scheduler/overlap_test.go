@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerOverlapPolicies(t *testing.T) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))), WithSkipIfRunning())
+
+	type counters struct{ runs, active, peak atomic.Int32 }
+	track := func(c *counters) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			c.runs.Add(1)
+			n := c.active.Add(1)
+			for p := c.peak.Load(); n > p && !c.peak.CompareAndSwap(p, n); p = c.peak.Load() {
+			}
+			time.Sleep(50 * time.Millisecond)
+			c.active.Add(-1)
+		}
+	}
+	jobs := map[string]*counters{"etl": {}, "heartbeat": {}, "ordered": {}}
+	if err := s.Every("etl", time.Hour, track(jobs["etl"])); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Every("heartbeat", time.Hour, track(jobs["heartbeat"]), Overlap(OverlapAllow)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Every("ordered", time.Hour, track(jobs["ordered"]), Overlap(OverlapDelay)); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for name := range jobs {
+		job := s.jobs[name]
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.execute(job, true)
+			}()
+		}
+	}
+	wg.Wait()
+
+	if c := jobs["etl"]; c.runs.Load() != 1 {
+		t.Errorf("etl (inherited skip) runs = %d, want 1", c.runs.Load())
+	}
+	if c := jobs["heartbeat"]; c.runs.Load() != 3 || c.peak.Load() < 2 {
+		t.Errorf("heartbeat (allow) runs = %d peak = %d, want 3 overlapping", c.runs.Load(), c.peak.Load())
+	}
+	if c := jobs["ordered"]; c.runs.Load() != 3 || c.peak.Load() != 1 {
+		t.Errorf("ordered (delay) runs = %d peak = %d, want 3 sequential", c.runs.Load(), c.peak.Load())
+	}
+
+	want := map[string]OverlapPolicy{"etl": OverlapSkip, "heartbeat": OverlapAllow, "ordered": OverlapDelay}
+	for _, j := range s.Jobs() {
+		if j.Overlap != want[j.Name] {
+			t.Errorf("Jobs()[%s].Overlap = %q, want %q", j.Name, j.Overlap, want[j.Name])
+		}
+	}
+	if j, _ := New().JobByName("x"); j.Overlap != "" {
+		t.Errorf("unknown job overlap = %q", j.Overlap)
+	}
+}
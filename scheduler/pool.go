@@ -0,0 +1,103 @@
+package scheduler
+
+import "sync"
+
+// defaultPoolQueue is the number of scheduled runs WithWorkerPool buffers by default.
+const defaultPoolQueue = 1024
+
+// WithWorkerPool runs scheduled job executions on a fixed pool of n workers instead of
+// a goroutine per run. Skip-if-running is applied before a run is queued; a run that
+// finds the queue full is skipped with SkipQueueFull and a warning. On Stop, queued
+// runs are dropped with SkipStopped and the Stop context waits for running ones.
+// RunNow is not routed through the pool. n <= 0 disables the pool.
+func WithWorkerPool(n int) Option {
+	return func(s *Scheduler) {
+		s.poolSize = n
+		if s.poolQueue == 0 {
+			s.poolQueue = defaultPoolQueue
+		}
+	}
+}
+
+// WithWorkerQueue sets how many scheduled runs WithWorkerPool may buffer.
+func WithWorkerQueue(size int) Option {
+	return func(s *Scheduler) {
+		s.poolQueue = size
+	}
+}
+
+type workerPool struct {
+	tasks chan func()
+	wg    sync.WaitGroup
+}
+
+func newWorkerPool(workers, queue int) *workerPool {
+	p := &workerPool{tasks: make(chan func(), max(queue, 0))}
+	p.wg.Add(workers)
+	for range workers {
+		go func() {
+			defer p.wg.Done()
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+	return p
+}
+
+// submit queues task without blocking, reporting whether there was room.
+func (p *workerPool) submit(task func()) bool {
+	select {
+	case p.tasks <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops the workers once the queue is drained. No submit may follow.
+func (p *workerPool) close() {
+	close(p.tasks)
+	p.wg.Wait()
+}
+
+// dispatch runs a scheduled fire of job, on the worker pool if there is one.
+func (s *Scheduler) dispatch(job *jobEntry) {
+	s.mu.RLock()
+	pool := s.pool
+	s.mu.RUnlock()
+	if pool == nil {
+		s.execute(job, false)
+		s.fired(job)
+		return
+	}
+
+	defer s.recoverRun(job)
+	if !s.beginRun(job, false) {
+		s.fired(job)
+		return
+	}
+	queued := pool.submit(func() {
+		defer s.fired(job)
+		defer s.recoverRun(job)
+		if s.jobContext().Err() != nil {
+			s.endRun(job)
+			s.jobSkipped(job, SkipStopped)
+			return
+		}
+		s.runBegun(job, false)
+	})
+	if !queued {
+		s.endRun(job)
+		job.log().Warn("job queue full", "queue", cap(pool.tasks))
+		s.jobSkipped(job, SkipQueueFull)
+		s.fired(job)
+	}
+}
+
+// fired finishes a scheduled fire of job: one-shot jobs are unregistered.
+func (s *Scheduler) fired(job *jobEntry) {
+	if job.once {
+		s.removeFired(job)
+	}
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerWorkerPoolBoundsGoroutines(t *testing.T) {
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithWorkerPool(4),
+	)
+	const jobs = 200
+	var ran atomic.Int32
+	for i := 0; i < jobs; i++ {
+		if err := s.Every(fmt.Sprintf("job-%d", i), time.Hour, func(ctx context.Context) {
+			ran.Add(1)
+			time.Sleep(time.Millisecond)
+		}); err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+	}
+	s.Start()
+	baseline := runtime.NumGoroutine()
+
+	peak := 0
+	for i := 0; i < jobs; i++ {
+		s.dispatch(s.jobs[fmt.Sprintf("job-%d", i)])
+		peak = max(peak, runtime.NumGoroutine())
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for ran.Load() < jobs && time.Now().Before(deadline) {
+		peak = max(peak, runtime.NumGoroutine())
+		time.Sleep(time.Millisecond)
+	}
+	if n := ran.Load(); n != jobs {
+		t.Fatalf("ran %d jobs, want %d", n, jobs)
+	}
+	if peak > baseline+2 {
+		t.Errorf("goroutines peaked at %d, baseline %d (pool workers already running)", peak, baseline)
+	}
+	if err := s.StopWithTimeout(2 * time.Second); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+}
+
+func TestSchedulerWorkerPoolQueueFull(t *testing.T) {
+	m := &recordingMetrics{errs: map[string]error{}}
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithWorkerPool(1),
+		WithWorkerQueue(1),
+		WithMetrics(m),
+	)
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	var ran atomic.Int32
+	for _, name := range []string{"a", "b", "c"} {
+		if err := s.Every(name, time.Hour, func(ctx context.Context) {
+			ran.Add(1)
+			started <- struct{}{}
+			<-release
+		}); err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+	}
+	s.Start()
+
+	s.dispatch(s.jobs["a"])
+	<-started               // a occupies the only worker
+	s.dispatch(s.jobs["b"]) // queued
+	s.dispatch(s.jobs["c"]) // queue full
+
+	stopped := s.Stop()
+	close(release)
+	select {
+	case <-stopped.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not drain the pool")
+	}
+
+	if n := ran.Load(); n != 1 {
+		t.Errorf("ran = %d, want only a (b dropped on Stop, c skipped)", n)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	want := map[string]bool{"skip:c:" + string(SkipQueueFull): false, "skip:b:" + string(SkipStopped): false}
+	for _, e := range m.events {
+		if _, ok := want[e]; ok {
+			want[e] = true
+		}
+	}
+	for e, seen := range want {
+		if !seen {
+			t.Errorf("missing metrics event %s in %v", e, m.events)
+		}
+	}
+}
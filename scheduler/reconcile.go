@@ -0,0 +1,171 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ez-api/foundation/logging/fields"
+	"github.com/robfig/cron/v3"
+)
+
+// JobSpec describes a desired job for Reconcile.
+type JobSpec struct {
+	Name string
+	// Schedule is a cron expression; it is ignored when Interval is set.
+	Schedule string
+	// Interval schedules the job with "@every <Interval>" when positive.
+	Interval time.Duration
+	Tags     []string
+	Options  []JobOption
+}
+
+func (spec JobSpec) schedule() string {
+	if spec.Interval > 0 {
+		return "@every " + spec.Interval.String()
+	}
+	return spec.Schedule
+}
+
+// JobFactory builds the job function for a spec. Reconcile calls it for added and changed jobs.
+type JobFactory func(spec JobSpec) (func(ctx context.Context), error)
+
+// ReloadFunc loads the desired job set, typically from a database.
+type ReloadFunc func(ctx context.Context) ([]JobSpec, error)
+
+// ReconcileResult lists the job names affected by a Reconcile call.
+type ReconcileResult struct {
+	Added   []string
+	Updated []string
+	Removed []string
+	Failed  map[string]error
+}
+
+// WithJobFactory sets the factory Reconcile uses to create job functions.
+func WithJobFactory(factory JobFactory) Option {
+	return func(s *Scheduler) {
+		s.factory = factory
+	}
+}
+
+// Reconcile makes the registered jobs match desired: missing jobs are created through the
+// job factory, jobs whose schedule or options (tags included) changed are rebuilt, and jobs
+// that are not desired are removed unless they are Protected. A job that fails to build or
+// parse keeps its previous registration (if any) and is reported in Failed. The factory
+// runs without the scheduler lock held, so it may call back into the scheduler.
+func (s *Scheduler) Reconcile(desired []JobSpec) ReconcileResult {
+	res := ReconcileResult{Failed: make(map[string]error)}
+	want := make(map[string]struct{}, len(desired))
+	type build struct {
+		job   *jobEntry
+		sched cron.Schedule
+	}
+	var changed []build
+	for _, spec := range desired {
+		if spec.Name == "" {
+			continue
+		}
+		if _, dup := want[spec.Name]; dup {
+			res.Failed[spec.Name] = errors.New("duplicate job spec")
+			continue
+		}
+		want[spec.Name] = struct{}{}
+
+		cfg := specConfig(spec)
+		s.mu.RLock()
+		existing, exists := s.jobs[spec.Name]
+		unchanged := exists && existing.schedule == spec.schedule() && existing.cfg.equal(cfg)
+		s.mu.RUnlock()
+		if unchanged {
+			continue
+		}
+		job, sched, err := s.buildSpec(spec)
+		if err != nil {
+			res.Failed[spec.Name] = err
+			continue
+		}
+		changed = append(changed, build{job, sched})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range changed {
+		if existing, exists := s.jobs[b.job.name]; exists {
+			s.cron.Remove(existing.entryID)
+			res.Updated = append(res.Updated, b.job.name)
+		} else {
+			res.Added = append(res.Added, b.job.name)
+		}
+		s.installLocked(b.job, b.sched)
+	}
+
+	for name, job := range s.jobs {
+		if _, ok := want[name]; ok || job.cfg.protected {
+			continue
+		}
+		s.cron.Remove(job.entryID)
+		delete(s.jobs, name)
+		res.Removed = append(res.Removed, name)
+	}
+
+	sort.Strings(res.Added)
+	sort.Strings(res.Updated)
+	sort.Strings(res.Removed)
+	s.logger.Info("jobs reconciled", "added", len(res.Added), "updated", len(res.Updated), "removed", len(res.Removed), "failed", len(res.Failed))
+	return res
+}
+
+// specOptions returns the job options spec registers with: its tags, then its Options.
+func specOptions(spec JobSpec) []JobOption {
+	return append([]JobOption{Tags(spec.Tags...)}, spec.Options...)
+}
+
+func specConfig(spec JobSpec) jobConfig {
+	var c jobConfig
+	for _, opt := range specOptions(spec) {
+		opt(&c)
+	}
+	return c
+}
+
+// buildSpec validates spec and builds its job through the factory. s.mu must not be held.
+func (s *Scheduler) buildSpec(spec JobSpec) (*jobEntry, cron.Schedule, error) {
+	if s.factory == nil {
+		return nil, nil, errors.New("no job factory configured")
+	}
+	if spec.Interval > 0 {
+		if err := s.checkInterval(spec.Interval); err != nil {
+			return nil, nil, err
+		}
+	}
+	sched, err := s.parse(spec.schedule())
+	if err != nil {
+		return nil, nil, err
+	}
+	fn, err := s.factory(spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build job: %w", err)
+	}
+	if fn == nil {
+		return nil, nil, errors.New("job factory returned nil function")
+	}
+	return newJobEntry(spec.Name, spec.schedule(), noError(fn), specOptions(spec)), sched, nil
+}
+
+// ReconcileEvery registers a protected job that periodically loads the desired job set
+// with load and reconciles against it.
+func (s *Scheduler) ReconcileEvery(name string, interval time.Duration, load ReloadFunc) error {
+	return s.Every(name, interval, func(ctx context.Context) {
+		desired, err := load(ctx)
+		if err != nil {
+			s.logger.Error("job reload failed", fields.JobName(name), "err", err)
+			return
+		}
+		res := s.Reconcile(desired)
+		for job, err := range res.Failed {
+			s.logger.Warn("job reconcile failed", fields.JobName(job), "err", err)
+		}
+	}, Protected())
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// JobError is the final error of a job run, after retries. Error returns Err's message
+// unchanged; Attempts tells how many times the job function was called.
+type JobError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *JobError) Error() string { return e.Err.Error() }
+
+func (e *JobError) Unwrap() error { return e.Err }
+
+// runWithRetry runs job.fn, retrying per the job's RetryPolicy, and returns the last
+// error as a *JobError.
+func (s *Scheduler) runWithRetry(ctx context.Context, job *jobEntry) error {
+	attempts, err := s.attempt(ctx, job)
+	if err != nil {
+		return &JobError{Attempts: attempts, Err: err}
+	}
+	return nil
+}
+
+// attempt calls job.fn until it succeeds, the policy is exhausted or ctx is done,
+// returning the number of calls and the last error.
+func (s *Scheduler) attempt(ctx context.Context, job *jobEntry) (int, error) {
+	p := job.cfg.retry
+	attempt := 1
+	err := job.fn(ctx)
+	for ; err != nil && attempt < p.MaxAttempts; attempt++ {
+		delay := p.backoff(attempt)
+		job.log().Warn("job failed, retrying", "attempt", attempt, "err", err, "delay", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-timer.C:
+		}
+		err = job.fn(ctx)
+	}
+	return attempt, err
+}
+
+// backoff returns the delay after the given failed attempt (1-based): BaseDelay doubled
+// per attempt, capped at MaxDelay, with "equal jitter" (uniform in [d/2, d)).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && d > 0; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int64N(int64(d-half)))
+}
@@ -0,0 +1,177 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{40, time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			d := p.backoff(tt.attempt)
+			if d < tt.max/2 || d >= tt.max {
+				t.Fatalf("backoff(%d) = %v, want in [%v, %v)", tt.attempt, d, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+func TestSchedulerRetry(t *testing.T) {
+	var failures []error
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithErrorHandler(func(_ string, err error) { failures = append(failures, err) }),
+	)
+	policy := Retry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+	calls := 0
+	if err := s.EveryE("flaky", time.Hour, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	}, policy); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.execute(s.jobs["flaky"], true)
+	if calls != 3 || len(failures) != 0 {
+		t.Errorf("flaky: calls = %d, failures = %v", calls, failures)
+	}
+
+	broken := 0
+	errBroken := errors.New("permanent")
+	if err := s.EveryE("broken", time.Hour, func(ctx context.Context) error {
+		broken++
+		return errBroken
+	}, policy); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.execute(s.jobs["broken"], true)
+	if broken != 3 || len(failures) != 1 || !errors.Is(failures[0], errBroken) {
+		t.Errorf("broken: calls = %d, failures = %v", broken, failures)
+	}
+}
+
+func TestSchedulerRetryStopsOnCancel(t *testing.T) {
+	base, cancel := context.WithCancel(context.Background())
+	s := New(WithBaseContext(base), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+	calls := 0
+	if err := s.EveryE("slow-retry", time.Hour, func(ctx context.Context) error {
+		calls++
+		cancel()
+		return errors.New("fail")
+	}, Retry(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Minute})); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.execute(s.jobs["slow-retry"], true)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("retry backoff did not stop on cancellation")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestSchedulerOnJobError(t *testing.T) {
+	got := map[string]error{}
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithOnJobError(func(name string, err error) { got[name] = err }),
+	)
+
+	errFlaky := errors.New("flaky upstream")
+	calls := 0
+	if err := s.EveryE("flaky", time.Hour, func(ctx context.Context) error {
+		calls++
+		return fmt.Errorf("attempt %d: %w", calls, errFlaky)
+	}, Retry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("boom", time.Hour, func(ctx context.Context) { panic("kaput") }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.execute(s.jobs["flaky"], true)
+	s.execute(s.jobs["boom"], true)
+
+	var je *JobError
+	if !errors.As(got["flaky"], &je) || je.Attempts != 3 {
+		t.Fatalf("flaky error = %#v, want *JobError after 3 attempts", got["flaky"])
+	}
+	if !errors.Is(got["flaky"], errFlaky) || got["flaky"].Error() != "attempt 3: flaky upstream" {
+		t.Errorf("flaky error = %q, want final attempt's error", got["flaky"])
+	}
+	if _, ok := got["boom"]; ok {
+		t.Errorf("callback fired for panic: %v", got["boom"])
+	}
+}
+
+func TestSchedulerRetryKeepsOverlapClaim(t *testing.T) {
+	var logs syncBuffer
+	var skipped []SkipReason
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithSkipIfRunning(),
+		WithOnJobSkip(func(_ string, r SkipReason) {
+			skipped = append(skipped, r)
+		}),
+	)
+	retrying := make(chan struct{})
+	var calls atomic.Int32
+	if err := s.EveryE("config-pull", time.Hour, func(ctx context.Context) error {
+		if calls.Add(1) == 2 {
+			close(retrying)
+		}
+		return errors.New("upstream unavailable")
+	}, Retry(RetryPolicy{MaxAttempts: 3, BaseDelay: 20 * time.Millisecond})); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	job := s.jobs["config-pull"]
+
+	done := make(chan struct{})
+	go func() {
+		s.execute(job, true)
+		close(done)
+	}()
+	<-retrying
+	s.execute(job, true) // overlaps the retrying run
+	<-done
+	if len(skipped) != 1 || skipped[0] != SkipStillRunning {
+		t.Fatalf("skips during retries = %v, want [still_running]", skipped)
+	}
+	if !strings.Contains(logs.String(), "attempts=3") {
+		t.Errorf("final failure log should carry the attempt count:\n%s", logs.String())
+	}
+
+	s.execute(job, true) // the next tick after retries finish runs normally
+	if n := calls.Load(); n != 6 || len(skipped) != 1 {
+		t.Errorf("calls = %d, skips = %v; want 6 calls and no new skip", n, skipped)
+	}
+}
@@ -5,25 +5,61 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"runtime/debug"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ez-api/foundation/logging"
+	"github.com/ez-api/foundation/logging/fields"
+	"github.com/ez-api/foundation/requestid"
 	"github.com/robfig/cron/v3"
 )
 
+var (
+	// ErrNotRunning is returned when an operation needs a started scheduler.
+	ErrNotRunning = errors.New("scheduler not running")
+	// ErrJobNotFound is returned for an unknown job name.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobExists is returned when registering a name that is already taken.
+	ErrJobExists = errors.New("job already exists")
+	// ErrDuplicateJob is an alias for ErrJobExists.
+	ErrDuplicateJob = ErrJobExists
+	// ErrInvalidInterval is returned by Every for a non-positive or sub-second interval.
+	ErrInvalidInterval = errors.New("invalid interval")
+)
+
 // Job represents a scheduled job with its metadata.
 type Job struct {
-	Name     string
-	Schedule string
-	EntryID  cron.EntryID
+	Name      string
+	Schedule  string
+	EntryID   cron.EntryID
+	Tags      []string
+	Protected bool
+	Overlap   OverlapPolicy // effective policy, after WithSkipIfRunning
+	Enabled   bool          // false after SetEnabled(name, false)
+	// Until is the job's end time (zero without Until); RemainingRuns is how many
+	// counted runs it has left (-1 without MaxRuns).
+	Until         time.Time
+	RemainingRuns int
+	// NextRun and PrevRun come from cron, in the scheduler's location. Both are zero
+	// until Start; PrevRun stays zero until the job has fired.
+	NextRun time.Time
+	PrevRun time.Time
+	Stats   JobStats
 }
 
 // Option configures the Scheduler.
 type Option func(*Scheduler)
 
 // WithBaseContext sets the base context used for all scheduled jobs.
-// A cancelable child context is created on Start() and canceled on Stop().
+// A fresh cancelable child context is created on every Start() and canceled on Stop();
+// values on ctx are visible to jobs across restarts.
 func WithBaseContext(ctx context.Context) Option {
 	return func(s *Scheduler) {
 		if ctx == nil {
@@ -33,6 +69,15 @@ func WithBaseContext(ctx context.Context) Option {
 	}
 }
 
+// WithPerRunValues sets fn to derive the context of each job run from the run context,
+// e.g. to attach a fresh request ID. fn must return ctx or a context derived from it,
+// so the run still sees Stop's cancellation; a nil result is ignored.
+func WithPerRunValues(fn func(ctx context.Context) context.Context) Option {
+	return func(s *Scheduler) {
+		s.perRun = fn
+	}
+}
+
 // WithLogger sets a custom logger for the scheduler.
 func WithLogger(logger *slog.Logger) Option {
 	return func(s *Scheduler) {
@@ -47,7 +92,71 @@ func WithLocation(loc *time.Location) Option {
 	}
 }
 
+// WithErrorHandler sets a callback for errors returned by jobs (EveryE, CronE) and for
+// recovered panics, which are passed as *PanicError. Errors are logged either way.
+func WithErrorHandler(fn func(jobName string, err error)) Option {
+	return func(s *Scheduler) {
+		s.onError = fn
+	}
+}
+
+// WithOnJobError sets a callback for errors returned by EveryE and CronE jobs, called
+// once the final attempt (see Retry) has failed. err is a *JobError.
+// Unlike WithErrorHandler it is never called for panics.
+func WithOnJobError(fn func(jobName string, err error)) Option {
+	return func(s *Scheduler) {
+		s.onJobError = fn
+	}
+}
+
+// PanicError wraps a value recovered from a panicking job.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("job panicked: %v", e.Value)
+}
+
+// WithJitter delays every scheduled run by a random duration in [0, max), recomputed per
+// run, to spread identical schedules across jobs and instances. This reduces
+// thundering-herd load on shared upstreams when many jobs fire on the same boundary
+// (e.g. every "@every 1m" job at :00). Stop interrupts a pending delay. Jobs can
+// override it with the Jitter option. RunNow is not delayed.
+func WithJitter(max time.Duration) Option {
+	return func(s *Scheduler) {
+		s.jitter = max
+	}
+}
+
+// Accepted cron formats, as reported in parse errors.
+const (
+	standardCronFormat = "5-field cron (minute hour day-of-month month day-of-week) or descriptor"
+	secondsCronFormat  = "5- or 6-field cron with optional leading seconds (WithSeconds) or descriptor"
+)
+
+// WithSeconds accepts six-field cron expressions with a leading seconds field, e.g.
+// "*/15 * * * * *". Five-field expressions keep working (seconds default to 0), as do
+// descriptors like "@every"; without this option six-field expressions are rejected.
+func WithSeconds() Option {
+	return func(s *Scheduler) {
+		s.parser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+		s.cronFormat = secondsCronFormat
+	}
+}
+
+// WithReplaceExisting makes Every, Cron and their E variants replace a job registered
+// under the same name instead of returning ErrJobExists. The old entry stops firing;
+// a run already in progress is not interrupted.
+func WithReplaceExisting() Option {
+	return func(s *Scheduler) {
+		s.replaceExisting = true
+	}
+}
+
 // WithSkipIfRunning prevents job overlap - skips execution if previous run is still active.
+// Jobs can override it with the Overlap option.
 func WithSkipIfRunning() Option {
 	return func(s *Scheduler) {
 		s.skipIfRunning = true
@@ -55,26 +164,125 @@ func WithSkipIfRunning() Option {
 }
 
 // Scheduler manages scheduled jobs using cron expressions or fixed intervals.
+// Jobs may be registered before or after Start: a job added while running shows up in
+// Jobs() immediately, fires on its schedule with the live run context, and is waited
+// for by Stop.
 type Scheduler struct {
-	cron          *cron.Cron
-	logger        *slog.Logger
-	location      *time.Location
-	skipIfRunning bool
-	jobs          map[string]Job
-	mu            sync.RWMutex
-	started       bool
-	baseCtx       context.Context
-	runCtx        context.Context
-	runCancel     context.CancelFunc
+	cron            *cron.Cron
+	logger          *slog.Logger
+	location        *time.Location
+	skipIfRunning   bool
+	replaceExisting bool
+	jobs            map[string]*jobEntry
+	mu              sync.RWMutex
+	started         bool
+	baseCtx         context.Context
+	runCtx          context.Context
+	runCancel       context.CancelFunc
+	stopped         context.Context // done once the last Stop has drained; nil before the first Stop
+	flights         flightGroup
+	parser          cron.ScheduleParser
+	cronFormat      string // describes parser's accepted format, for errors
+	factory         JobFactory
+	clock           Clock
+	clockGuard      time.Duration
+	manualRuns      sync.WaitGroup
+	jitter          time.Duration
+	vars            *expvars
+	expvarPrefix    string // set by WithExpvar
+	onError         func(jobName string, err error)
+	onJobError      func(jobName string, err error)
+	metrics         MetricsHook
+	locker          Locker
+	maxDrift        time.Duration
+	onDrift         func(jobName string, drift time.Duration)
+	slots           chan struct{}
+	middleware      []JobMiddleware
+	perRun          func(ctx context.Context) context.Context
+	poolSize        int
+	poolQueue       int
+	pool            *workerPool // non-nil while started with WithWorkerPool
+	slotWait        time.Duration
+	active          atomic.Int32 // job functions executing; see ActiveRuns
+	onJobStart      func(name string)
+	onJobFinish     func(name string, dur time.Duration, recovered any)
+	onJobSkip       func(name string, reason SkipReason)
+}
+
+// jobEntry is the scheduler's internal record of a registered job.
+type jobEntry struct {
+	name     string
+	schedule string
+	entryID  cron.EntryID
+	fn       func(ctx context.Context) error
+	cfg      jobConfig
+	interval time.Duration // set for @every schedules
+	once     bool          // set for At/After jobs
+	last     fireClock
+	logger   atomic.Pointer[slog.Logger] // set by installLocked
+	running  atomic.Bool                 // held during runs under OverlapSkip
+	disabled atomic.Bool                 // set by SetEnabled
+	runs     atomic.Int64                // runs counted against MaxRuns
+	delay    sync.Mutex                  // held during runs under OverlapDelay
+	stats    statsRecorder
+}
+
+// viewLocked returns the public view of job. s.mu must be held.
+func (s *Scheduler) viewLocked(j *jobEntry) Job {
+	next, prev := s.runTimesLocked(j, false)
+	return Job{
+		Name:          j.name,
+		Schedule:      j.schedule,
+		EntryID:       j.entryID,
+		Tags:          append([]string(nil), j.cfg.tags...),
+		Protected:     j.cfg.protected,
+		Overlap:       s.overlapPolicy(j),
+		Enabled:       !j.disabled.Load(),
+		Until:         j.cfg.until,
+		RemainingRuns: remainingRuns(j),
+		NextRun:       next,
+		PrevRun:       prev,
+		Stats:         j.stats.snapshot(),
+	}
+}
+
+// runTimesLocked returns the job's next and previous run times in s.location, as
+// tracked by cron (zero before Start). With estimate, a missing next run is computed
+// from the schedule instead. s.mu must be held.
+func (s *Scheduler) runTimesLocked(j *jobEntry, estimate bool) (next, prev time.Time) {
+	entry := s.cron.Entry(j.entryID)
+	if !entry.Valid() {
+		return time.Time{}, time.Time{}
+	}
+	next = entry.Next
+	if next.IsZero() && estimate && entry.Schedule != nil {
+		// cron only computes Next once started.
+		if once, ok := entry.Schedule.(onceSchedule); ok {
+			next = once.at // Next would consume an overdue schedule's single fire
+		} else {
+			next = entry.Schedule.Next(s.clock.Now().In(s.location))
+		}
+	}
+	if !next.IsZero() {
+		next = next.In(s.location)
+	}
+	if !entry.Prev.IsZero() {
+		prev = entry.Prev.In(s.location)
+	}
+	return next, prev
 }
 
 // New creates a new Scheduler with the given options.
 func New(opts ...Option) *Scheduler {
 	s := &Scheduler{
-		logger:   slog.Default(),
-		location: time.UTC,
-		baseCtx:  context.Background(),
-		jobs:     make(map[string]Job),
+		logger:     slog.Default(),
+		location:   time.UTC,
+		baseCtx:    context.Background(),
+		jobs:       make(map[string]*jobEntry),
+		parser:     cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor),
+		cronFormat: standardCronFormat,
+		clock:      systemClock{origin: time.Now()},
+		metrics:    noopMetrics{},
 	}
 
 	for _, opt := range opts {
@@ -83,73 +291,359 @@ func New(opts ...Option) *Scheduler {
 
 	// Build cron options
 	cronOpts := []cron.Option{
+		cron.WithParser(s.parser),
 		cron.WithLocation(s.location),
-		cron.WithLogger(&cronLogAdapter{logger: s.logger}),
+		cron.WithLogger(logging.InfoErrorLogger(s.logger)),
 	}
 
-	// Build chain with panic recovery and optional skip-if-running
-	var chain []cron.JobWrapper
-	chain = append(chain, cron.Recover(&cronLogAdapter{logger: s.logger}))
-	if s.skipIfRunning {
-		chain = append(chain, cron.SkipIfStillRunning(&cronLogAdapter{logger: s.logger}))
-	}
-	cronOpts = append(cronOpts, cron.WithChain(chain...))
-
+	// Panic recovery and skip-if-running are applied by execute, so that
+	// RunNow shares them with scheduled ticks.
 	s.cron = cron.New(cronOpts...)
+	if s.expvarPrefix != "" {
+		if err := s.PublishExpvar(s.expvarPrefix); err != nil {
+			s.logger.Error("scheduler expvars not published", "prefix", s.expvarPrefix, "err", err)
+		}
+	}
 	return s
 }
 
 // Every schedules a job to run at fixed intervals.
 // The interval string should be a duration like "5m", "1h", "30s".
-func (s *Scheduler) Every(name string, interval time.Duration, fn func(ctx context.Context)) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// It returns ErrJobExists if name is taken, unless WithReplaceExisting is set.
+func (s *Scheduler) Every(name string, interval time.Duration, fn func(ctx context.Context), opts ...JobOption) error {
+	return s.EveryE(name, interval, noError(fn), opts...)
+}
+
+// EveryE is Every for job functions that return an error; see WithErrorHandler.
+func (s *Scheduler) EveryE(name string, interval time.Duration, fn func(ctx context.Context) error, opts ...JobOption) error {
+	if err := s.checkInterval(interval); err != nil {
+		return err
+	}
+	return s.add(name, "@every "+interval.String(), fn, opts)
+}
+
+// checkInterval rejects intervals cron would not honour: non-positive ones, and
+// sub-second ones, which cron rounds up to 1s whatever the parser (WithSeconds included).
+func (s *Scheduler) checkInterval(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: must be positive, got %s", ErrInvalidInterval, interval)
+	}
+	if interval < time.Second {
+		return fmt.Errorf("%w: must be at least 1s, got %s", ErrInvalidInterval, interval)
+	}
+	return nil
+}
+
+// Cron schedules a job using a cron expression.
+// The expression uses standard 5-field format: minute hour day-of-month month day-of-week
+// Examples: "0 * * * *" (every hour), "0 0 * * *" (daily at midnight)
+// With WithSeconds a leading seconds field may be added: "*/15 * * * * *".
+// Like Every, it returns ErrJobExists if name is taken, unless WithReplaceExisting is set.
+func (s *Scheduler) Cron(name string, expr string, fn func(ctx context.Context), opts ...JobOption) error {
+	return s.add(name, expr, noError(fn), opts)
+}
+
+// CronE is Cron for job functions that return an error; see WithErrorHandler.
+func (s *Scheduler) CronE(name string, expr string, fn func(ctx context.Context) error, opts ...JobOption) error {
+	return s.add(name, expr, fn, opts)
+}
 
-	// Wrap the function to include context
-	wrappedFn := func() {
-		ctx := s.jobContext()
+func noError(fn func(ctx context.Context)) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
 		fn(ctx)
+		return nil
 	}
+}
 
-	entryID, err := s.cron.AddFunc("@every "+interval.String(), wrappedFn)
+func (s *Scheduler) add(name, spec string, fn func(ctx context.Context) error, opts []JobOption) error {
+	sched, err := s.parse(spec)
 	if err != nil {
 		return err
 	}
+	return s.addSchedule(newJobEntry(name, spec, fn, opts), sched, s.replaceExisting)
+}
 
-	s.jobs[name] = Job{
-		Name:     name,
-		Schedule: "@every " + interval.String(),
-		EntryID:  entryID,
+// Replace registers fn under name with the cron expression expr (descriptors such as
+// "@every 5m" included), first removing any job of that name so its old entry stops
+// firing. A run of the old job already in progress is not interrupted.
+func (s *Scheduler) Replace(name, expr string, fn func(ctx context.Context), opts ...JobOption) error {
+	sched, err := s.parse(expr)
+	if err != nil {
+		return err
 	}
+	return s.addSchedule(newJobEntry(name, expr, noError(fn), opts), sched, true)
+}
 
-	s.logger.Debug("job scheduled", "name", name, "schedule", "@every "+interval.String())
+// addSchedule registers job under sched, replacing a job of the same name if replace
+// is set and rejecting it otherwise.
+func (s *Scheduler) addSchedule(job *jobEntry, sched cron.Schedule, replace bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, exists := s.jobs[job.name]
+	if exists && !replace {
+		return fmt.Errorf("%w: %s", ErrJobExists, job.name)
+	}
+	if exists {
+		s.cron.Remove(existing.entryID)
+	}
+	s.installLocked(job, sched)
+	job.log().Debug("job scheduled", "replaced", exists)
 	return nil
 }
 
-// Cron schedules a job using a cron expression.
-// The expression uses standard 5-field format: minute hour day-of-month month day-of-week
-// Examples: "0 * * * *" (every hour), "0 0 * * *" (daily at midnight)
-func (s *Scheduler) Cron(name string, expr string, fn func(ctx context.Context)) error {
+func newJobEntry(name, spec string, fn func(ctx context.Context) error, opts []JobOption) *jobEntry {
+	job := &jobEntry{name: name, schedule: spec, fn: fn}
+	for _, opt := range opts {
+		opt(&job.cfg)
+	}
+	return job
+}
+
+// parse parses a schedule spec, naming the active cron format in errors.
+func (s *Scheduler) parse(spec string) (cron.Schedule, error) {
+	sched, err := s.parser.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: want %s: %w", spec, s.cronFormat, err)
+	}
+	return sched, nil
+}
+
+// installLocked registers job with cron under sched and records it. s.mu must be held.
+func (s *Scheduler) installLocked(job *jobEntry, sched cron.Schedule) {
+	job.interval = 0
+	if every, ok := sched.(cron.ConstantDelaySchedule); ok {
+		job.interval = every.Delay
+	}
+	job.logger.Store(s.logger.With(fields.JobName(job.name), "schedule", job.schedule))
+	job.entryID = s.cron.Schedule(sched, cron.FuncJob(func() { s.dispatch(job) }))
+	s.jobs[job.name] = job
+}
+
+// log returns the job's logger: the scheduler logger with job_name and schedule attached.
+func (j *jobEntry) log() *slog.Logger {
+	if l := j.logger.Load(); l != nil {
+		return l
+	}
+	return slog.Default().With(fields.JobName(j.name))
+}
+
+// Reschedule replaces the named job's schedule with the cron expression expr, keeping
+// its function and options. If expr does not parse, the current schedule stays active.
+func (s *Scheduler) Reschedule(name, expr string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	wrappedFn := func() {
-		ctx := s.jobContext()
-		fn(ctx)
+	job, ok := s.jobs[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
 	}
-
-	entryID, err := s.cron.AddFunc(expr, wrappedFn)
+	sched, err := s.parse(expr)
 	if err != nil {
 		return err
 	}
+	s.cron.Remove(job.entryID)
+	job.schedule = expr
+	s.installLocked(job, sched)
+	job.log().Debug("job rescheduled")
+	return nil
+}
 
-	s.jobs[name] = Job{
-		Name:     name,
-		Schedule: expr,
-		EntryID:  entryID,
+// RescheduleEvery is Reschedule for a fixed interval.
+func (s *Scheduler) RescheduleEvery(name string, interval time.Duration) error {
+	if err := s.checkInterval(interval); err != nil {
+		return err
 	}
+	return s.Reschedule(name, "@every "+interval.String())
+}
+
+// UpdateSchedule is an alias for Reschedule: the job keeps its name, function,
+// options and Stats, and a bad expression leaves the current schedule in place.
+func (s *Scheduler) UpdateSchedule(name, expr string) error {
+	return s.Reschedule(name, expr)
+}
+
+// UpdateInterval is an alias for RescheduleEvery.
+func (s *Scheduler) UpdateInterval(name string, interval time.Duration) error {
+	return s.RescheduleEvery(name, interval)
+}
 
-	s.logger.Debug("job scheduled", "name", name, "schedule", expr)
+// execute runs a single invocation of job, applying its per-job options. Manual
+// invocations (RunNow) bypass the clock guard but otherwise behave like scheduled ticks;
+// neither runs while the job is disabled.
+func (s *Scheduler) execute(job *jobEntry, manual bool) {
+	defer s.recoverRun(job)
+	if job.disabled.Load() {
+		job.stats.disabledSkip()
+		job.log().Debug("job disabled, skipping")
+		return
+	}
+	if s.beginRun(job, manual) {
+		s.runBegun(job, manual)
+	}
+}
+
+// recoverRun reports a panic that escaped the job wrapper itself. It must be deferred.
+func (s *Scheduler) recoverRun(job *jobEntry) {
+	if r := recover(); r != nil {
+		s.jobFailed(job, &PanicError{Value: r, Stack: debug.Stack()})
+	}
+}
+
+// beginRun records drift and applies job's overlap policy. If it returns true
+// the caller must follow with runBegun, which releases the claim.
+func (s *Scheduler) beginRun(job *jobEntry, manual bool) bool {
+	if !manual {
+		s.recordDrift(job)
+	}
+	return s.claimOverlap(job)
+}
+
+// endRun releases the claim taken by beginRun.
+func (s *Scheduler) endRun(job *jobEntry) {
+	s.releaseOverlap(job)
+}
+
+// runBegun runs job after a successful beginRun, applying the remaining per-job options.
+func (s *Scheduler) runBegun(job *jobEntry, manual bool) {
+	defer s.endRun(job)
+	if s.expired(job) {
+		return
+	}
+	if !manual && job.interval > 0 && !s.admitInterval(job) {
+		return
+	}
+	if !manual && !s.sleepJitter(job) {
+		return
+	}
+	if key := job.cfg.singleflightKey; key != "" {
+		release, ok := s.flights.acquire(key, job.cfg.singleflightWait)
+		if !ok {
+			s.jobSkipped(job, SkipCoalesced, "singleflight_key", key)
+			return
+		}
+		defer release()
+	}
+
+	releaseSlot, ok := s.acquireSlot(job)
+	if !ok {
+		return
+	}
+	defer releaseSlot()
+
+	runID := requestid.New()
+	ctx := withRun(s.jobContext(), job.name, runID)
+	if s.perRun != nil {
+		if derived := s.perRun(ctx); derived != nil {
+			ctx = derived
+		}
+	}
+	unlock, ok := s.acquireLock(ctx, job)
+	if !ok {
+		return
+	}
+	defer unlock()
+	if s.exhausted(job) {
+		return
+	}
+	if d := job.cfg.timeout; d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+		start := time.Now()
+		stop := context.AfterFunc(ctx, func() {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				job.log().Warn("job timed out", "timeout", d, "elapsed", time.Since(start))
+			}
+		})
+		defer stop()
+	}
+	start, mono := s.clock.Now(), s.clock.Monotonic()
+	s.vars.executed(job.name, start)
+	job.stats.started(start)
+	s.metrics.JobStarted(job.name)
+	s.jobStartHook(job.name)
+	job.log().Debug("job started", fields.RunID(runID), "manual", manual)
+	s.active.Add(1)
+	err := s.run(ctx, job)
+	s.active.Add(-1)
+	elapsed := s.clock.Monotonic() - mono
+	job.log().Debug("job finished", fields.RunID(runID), "duration", elapsed, "failed", err != nil)
+	job.stats.finished(s.clock.Now(), elapsed, err)
+	s.metrics.JobCompleted(job.name, elapsed, err)
+	s.jobFinishHook(job.name, elapsed, err)
+	if err != nil {
+		s.jobFailed(job, err)
+	}
+	s.countRun(job, err)
+}
+
+// run calls the job (with retries and middleware), converting a panic into a *PanicError.
+func (s *Scheduler) run(ctx context.Context, job *jobEntry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	if len(s.middleware) > 0 {
+		return s.runMiddleware(ctx, job)
+	}
+	return s.runWithRetry(ctx, job)
+}
+
+// jobFailed logs err and passes it to the error handler, if any.
+func (s *Scheduler) jobFailed(job *jobEntry, err error) {
+	name := job.name
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		job.log().Error("job panicked", "panic", pe.Value, "stack", string(pe.Stack))
+	} else {
+		args := []any{"err", err}
+		var je *JobError
+		if errors.As(err, &je) && je.Attempts > 1 {
+			args = append(args, "attempts", je.Attempts)
+		}
+		job.log().Error("job failed", args...)
+		if s.onJobError != nil {
+			s.onJobError(name, err)
+		}
+	}
+	s.vars.failed(name)
+	if s.onError != nil {
+		s.onError(name, err)
+	}
+}
+
+// jobSkipped records a run that did not execute the job body.
+func (s *Scheduler) jobSkipped(job *jobEntry, reason SkipReason, args ...any) {
+	name := job.name
+	job.log().Debug("job skipped", append([]any{"reason", reason}, args...)...)
+	job.stats.skipped()
+	s.vars.skipped(name)
+	s.metrics.JobSkipped(name, reason)
+	s.jobSkipHook(name, reason)
+}
+
+// RunNow triggers the named job immediately in a new goroutine, with the live run
+// context. Panic recovery and skip-if-running apply as for scheduled ticks.
+func (s *Scheduler) RunNow(name string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.started {
+		return ErrNotRunning
+	}
+	job, ok := s.jobs[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	// Registered under the lock so Stop, which clears started first, always waits for it.
+	s.manualRuns.Add(1)
+	go func() {
+		defer s.manualRuns.Done()
+		s.execute(job, true)
+	}()
+	job.log().Debug("job triggered manually")
 	return nil
 }
 
@@ -163,9 +657,27 @@ func (s *Scheduler) Remove(name string) bool {
 		return false
 	}
 
-	s.cron.Remove(job.EntryID)
+	s.cron.Remove(job.entryID)
 	delete(s.jobs, name)
-	s.logger.Debug("job removed", "name", name)
+	job.log().Debug("job removed")
+	return true
+}
+
+// SetEnabled turns the named job on or off without touching its schedule. A disabled
+// job stays registered and its cron entry keeps firing, but each fire (and RunNow) is
+// dropped with a debug log and counted in JobStats.DisabledSkips. Runs already past
+// that check finish normally. It returns false if the job does not exist.
+func (s *Scheduler) SetEnabled(name string, enabled bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return false
+	}
+	if job.disabled.Swap(!enabled) != !enabled {
+		job.log().Info("job enabled state changed", "enabled", enabled)
+	}
 	return true
 }
 
@@ -176,11 +688,23 @@ func (s *Scheduler) Jobs() []Job {
 
 	result := make([]Job, 0, len(s.jobs))
 	for _, job := range s.jobs {
-		result = append(result, job)
+		result = append(result, s.viewLocked(job))
 	}
 	return result
 }
 
+// JobByName returns the named job.
+func (s *Scheduler) JobByName(name string) (Job, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return Job{}, false
+	}
+	return s.viewLocked(job), true
+}
+
 // Start begins executing scheduled jobs.
 func (s *Scheduler) Start() {
 	s.mu.Lock()
@@ -191,12 +715,17 @@ func (s *Scheduler) Start() {
 	}
 
 	s.runCtx, s.runCancel = context.WithCancel(s.baseContext())
+	if s.poolSize > 0 {
+		s.pool = newWorkerPool(s.poolSize, s.poolQueue)
+	}
 	s.cron.Start()
 	s.started = true
 	s.logger.Info("scheduler started", "jobs", len(s.jobs))
 }
 
-// Stop stops the scheduler and waits for running jobs to complete.
+// Stop stops the scheduler and cancels the run context. The returned context is done
+// once every running job has returned, including jobs registered after Start and
+// runs triggered by RunNow.
 func (s *Scheduler) Stop() context.Context {
 	s.mu.Lock()
 	if !s.started {
@@ -209,13 +738,67 @@ func (s *Scheduler) Stop() context.Context {
 	s.started = false
 	cancel := s.runCancel
 	s.runCancel = nil
+	pool := s.pool
+	s.pool = nil
+	ctx, done := context.WithCancel(context.Background())
+	s.stopped = ctx
 	s.mu.Unlock()
 
 	s.logger.Info("scheduler stopping")
 	if cancel != nil {
 		cancel()
 	}
-	return s.cron.Stop()
+	cronDone := s.cron.Stop()
+	go func() {
+		<-cronDone.Done()
+		if pool != nil {
+			pool.close() // cron has returned, so nothing submits any more
+		}
+		s.manualRuns.Wait()
+		done()
+	}()
+	return ctx
+}
+
+// StopWait stops the scheduler like Stop and waits until every running job has
+// returned or ctx is done. In the latter case it returns an error wrapping ctx.Err()
+// (e.g. context.DeadlineExceeded) that names the jobs still running. It is safe to
+// call concurrently with Stop, and returns nil at once if the scheduler never started.
+func (s *Scheduler) StopWait(ctx context.Context) error {
+	s.Stop()
+	s.mu.RLock()
+	stopped := s.stopped // also covers a concurrent Stop that won the race
+	s.mu.RUnlock()
+	if stopped == nil {
+		return nil
+	}
+	select {
+	case <-stopped.Done():
+		return nil
+	case <-ctx.Done():
+	}
+	return fmt.Errorf("%w: jobs still running: %s", ctx.Err(), strings.Join(s.runningJobs(), ", "))
+}
+
+// StopWithTimeout is StopWait with a deadline d from now.
+func (s *Scheduler) StopWithTimeout(d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return s.StopWait(ctx)
+}
+
+// runningJobs returns the sorted names of registered jobs with a run in progress.
+func (s *Scheduler) runningJobs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var names []string
+	for name, job := range s.jobs {
+		if job.stats.snapshot().Running > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 // Running returns true if the scheduler is running.
@@ -232,29 +815,16 @@ func (s *Scheduler) baseContext() context.Context {
 	return context.Background()
 }
 
+// jobContext returns the context passed to jobs. It is always a descendant of the base
+// context, so base values are visible:
+//   - while running, it is the context derived by the latest Start;
+//   - after Stop, it is that same (now canceled) context, so late invocations see Done;
+//   - before the first Start, it is the base context itself.
 func (s *Scheduler) jobContext() context.Context {
 	s.mu.RLock()
-	ctx := s.runCtx
-	if ctx == nil {
-		ctx = s.baseCtx
-	}
-	s.mu.RUnlock()
-	if ctx != nil {
-		return ctx
+	defer s.mu.RUnlock()
+	if s.runCtx != nil {
+		return s.runCtx
 	}
-	return context.Background()
-}
-
-// cronLogAdapter adapts slog.Logger to cron.Logger interface.
-type cronLogAdapter struct {
-	logger *slog.Logger
-}
-
-func (a *cronLogAdapter) Info(msg string, keysAndValues ...interface{}) {
-	a.logger.Info(msg, keysAndValues...)
-}
-
-func (a *cronLogAdapter) Error(err error, msg string, keysAndValues ...interface{}) {
-	args := append([]interface{}{"err", err}, keysAndValues...)
-	a.logger.Error(msg, args...)
+	return s.baseContext()
 }
@@ -1,7 +1,13 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -12,7 +18,7 @@ func TestSchedulerEvery(t *testing.T) {
 	s := New()
 
 	var counter int32
-	err := s.Every("test-job", 100*time.Millisecond, func(ctx context.Context) {
+	err := s.Every("test-job", time.Second, func(ctx context.Context) {
 		atomic.AddInt32(&counter, 1)
 	})
 	if err != nil {
@@ -170,7 +176,7 @@ func TestSchedulerBaseContextValue(t *testing.T) {
 	s := New(WithBaseContext(baseCtx))
 
 	ch := make(chan any, 1)
-	err := s.Every("ctx-job", 100*time.Millisecond, func(ctx context.Context) {
+	err := s.Every("ctx-job", time.Second, func(ctx context.Context) {
 		select {
 		case ch <- ctx.Value(key):
 		default:
@@ -201,7 +207,7 @@ func TestSchedulerStopCancelsJobContext(t *testing.T) {
 	var startedOnce sync.Once
 	var doneOnce sync.Once
 
-	err := s.Every("ctx-cancel-job", 100*time.Millisecond, func(ctx context.Context) {
+	err := s.Every("ctx-cancel-job", time.Second, func(ctx context.Context) {
 		startedOnce.Do(func() { close(started) })
 		<-ctx.Done()
 		doneOnce.Do(func() { close(done) })
@@ -296,3 +302,860 @@ func TestSchedulerInvalidCronExpression(t *testing.T) {
 		t.Error("expected error for invalid cron expression")
 	}
 }
+
+func TestSchedulerStatus(t *testing.T) {
+	s := New()
+	s.Every("b-job", time.Minute, func(ctx context.Context) {})
+	s.Cron("a-job", "0 0 * * *", func(ctx context.Context) {})
+
+	st := s.Status()
+	if st.Running {
+		t.Error("expected not running")
+	}
+	if len(st.Jobs) != 2 || st.Jobs[0].Name != "a-job" || st.Jobs[1].Name != "b-job" {
+		t.Fatalf("unexpected jobs: %+v", st.Jobs)
+	}
+	if err := st.Validate(); err != nil {
+		t.Fatalf("status should be valid before start: %v", err)
+	}
+	if st.Jobs[0].PrevRun != nil {
+		t.Error("expected no prev run before start")
+	}
+}
+
+func TestSchedulerSingleflightKey(t *testing.T) {
+	for _, wait := range []bool{false, true} {
+		s := New()
+
+		var bodies int32
+		release := make(chan struct{})
+		started := make(chan struct{}, 2)
+		fn := func(ctx context.Context) {
+			atomic.AddInt32(&bodies, 1)
+			started <- struct{}{}
+			<-release
+		}
+		opts := []JobOption{SingleflightKey("refresh-catalog")}
+		if wait {
+			opts = append(opts, SingleflightWait())
+		}
+		if err := s.Every("refresh-catalog-fast", time.Minute, fn, opts...); err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+		if err := s.Every("refresh-catalog-slow", time.Hour, fn, opts...); err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+
+		first := make(chan struct{})
+		go func() {
+			s.execute(s.jobs["refresh-catalog-fast"], false)
+			close(first)
+		}()
+		<-started
+
+		second := make(chan struct{})
+		go func() {
+			s.execute(s.jobs["refresh-catalog-slow"], false)
+			close(second)
+		}()
+
+		if !wait {
+			select {
+			case <-second:
+			case <-time.After(time.Second):
+				t.Fatal("coalesced trigger should return immediately")
+			}
+		} else {
+			select {
+			case <-second:
+				t.Fatal("coalesced trigger should wait for the in-flight run")
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+
+		close(release)
+		<-first
+		<-second
+
+		if got := atomic.LoadInt32(&bodies); got != 1 {
+			t.Errorf("wait=%v: expected exactly one body execution, got %d", wait, got)
+		}
+	}
+}
+
+func TestSchedulerReconcile(t *testing.T) {
+	var built []string
+	s := New(WithJobFactory(func(spec JobSpec) (func(ctx context.Context), error) {
+		if spec.Name == "broken" {
+			return nil, errors.New("unknown job type")
+		}
+		built = append(built, spec.Name)
+		return func(ctx context.Context) {}, nil
+	}))
+	if err := s.Every("self-reconcile", time.Minute, func(ctx context.Context) {}, Protected()); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	res := s.Reconcile([]JobSpec{
+		{Name: "a", Interval: time.Minute},
+		{Name: "b", Schedule: "0 * * * *", Tags: []string{"sync"}},
+		{Name: "broken", Interval: time.Minute},
+	})
+	if fmt.Sprint(res.Added) != "[a b]" || len(res.Updated) != 0 || len(res.Removed) != 0 || res.Failed["broken"] == nil {
+		t.Fatalf("first reconcile: %+v", res)
+	}
+
+	res = s.Reconcile([]JobSpec{
+		{Name: "a", Interval: time.Minute},
+		{Name: "b", Schedule: "30 * * * *", Tags: []string{"sync"}},
+		{Name: "c", Schedule: "bad expression"},
+	})
+	if len(res.Added) != 0 || fmt.Sprint(res.Updated) != "[b]" || len(res.Removed) != 0 || res.Failed["c"] == nil {
+		t.Fatalf("second reconcile: %+v", res)
+	}
+
+	res = s.Reconcile([]JobSpec{
+		{Name: "b", Schedule: "30 * * * *", Tags: []string{"sync", "hourly"}},
+		{Name: "d", Interval: time.Hour},
+	})
+	if fmt.Sprint(res.Added) != "[d]" || fmt.Sprint(res.Updated) != "[b]" || fmt.Sprint(res.Removed) != "[a]" || len(res.Failed) != 0 {
+		t.Fatalf("third reconcile: %+v", res)
+	}
+
+	got := map[string]Job{}
+	for _, job := range s.Jobs() {
+		got[job.Name] = job
+	}
+	if len(got) != 3 || !got["self-reconcile"].Protected {
+		t.Fatalf("unexpected final job set: %+v", got)
+	}
+	if got["b"].Schedule != "30 * * * *" || fmt.Sprint(got["b"].Tags) != "[sync hourly]" {
+		t.Errorf("b not updated: %+v", got["b"])
+	}
+	if got["d"].Schedule != "@every 1h0m0s" {
+		t.Errorf("d schedule = %q", got["d"].Schedule)
+	}
+	if len(s.cron.Entries()) != 3 {
+		t.Errorf("expected 3 cron entries, got %d", len(s.cron.Entries()))
+	}
+	if fmt.Sprint(built) != "[a b b b d]" {
+		t.Errorf("factory calls = %v", built)
+	}
+}
+
+func TestSchedulerReconcileOptionsAndReentrancy(t *testing.T) {
+	var s *Scheduler
+	built := 0
+	s = New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))), WithJobFactory(func(spec JobSpec) (func(ctx context.Context), error) {
+		built++
+		_ = s.Jobs() // a factory may call back into the scheduler
+		return func(ctx context.Context) {}, nil
+	}))
+
+	done := make(chan ReconcileResult, 1)
+	go func() {
+		done <- s.Reconcile([]JobSpec{{Name: "a", Interval: time.Minute, Options: []JobOption{Timeout(time.Second)}}})
+	}()
+	select {
+	case res := <-done:
+		if fmt.Sprint(res.Added) != "[a]" {
+			t.Fatalf("first reconcile: %+v", res)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Reconcile deadlocked on a re-entrant factory")
+	}
+
+	res := s.Reconcile([]JobSpec{{Name: "a", Interval: time.Minute, Options: []JobOption{Timeout(time.Second)}}})
+	if len(res.Added)+len(res.Updated) != 0 || built != 1 {
+		t.Fatalf("identical spec should not rebuild: %+v, built %d", res, built)
+	}
+	res = s.Reconcile([]JobSpec{{Name: "a", Interval: time.Minute, Options: []JobOption{Timeout(2 * time.Second)}}})
+	if fmt.Sprint(res.Updated) != "[a]" || s.jobs["a"].cfg.timeout != 2*time.Second {
+		t.Fatalf("option change should rebuild: %+v", res)
+	}
+	res = s.Reconcile([]JobSpec{{Name: "a", Interval: time.Minute, Options: []JobOption{Timeout(2 * time.Second), Jitter(time.Second)}}})
+	if fmt.Sprint(res.Updated) != "[a]" {
+		t.Fatalf("added option should rebuild: %+v", res)
+	}
+
+	res = s.Reconcile([]JobSpec{{Name: "fast", Interval: 100 * time.Millisecond}})
+	if !errors.Is(res.Failed["fast"], ErrInvalidInterval) || len(res.Added) != 0 {
+		t.Fatalf("sub-second interval: %+v", res)
+	}
+}
+
+func TestSchedulerBaseContextAcrossRestart(t *testing.T) {
+	type ctxKey struct{}
+	base := context.WithValue(context.Background(), ctxKey{}, "tenant-a")
+
+	seen := make(chan context.Context, 16)
+	s := New(WithBaseContext(base))
+	if err := s.Every("ctx-job", time.Second, func(ctx context.Context) {
+		seen <- ctx
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	if got := s.jobContext().Value(ctxKey{}); got != "tenant-a" {
+		t.Fatalf("before start: value = %v", got)
+	}
+
+	s.Start()
+	first := s.jobContext()
+	<-s.Stop().Done()
+	if first.Err() == nil {
+		t.Fatal("first run context should be canceled after Stop")
+	}
+
+	s.Start()
+	defer s.Stop()
+
+	// Skip invocations from the first run and wait for one from the second.
+	ctx := first
+	for ctx == first {
+		select {
+		case ctx = <-seen:
+		case <-time.After(2 * time.Second):
+			t.Fatal("job did not run after restart")
+		}
+	}
+
+	if got := ctx.Value(ctxKey{}); got != "tenant-a" {
+		t.Errorf("after restart: value = %v, want tenant-a", got)
+	}
+	if ctx.Err() != nil {
+		t.Errorf("new run context should not be done: %v", ctx.Err())
+	}
+	if first.Err() == nil {
+		t.Error("old run context should stay done")
+	}
+}
+
+func TestSchedulerRunNow(t *testing.T) {
+	s := New(WithSkipIfRunning(), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+	var runs int32
+	release := make(chan struct{})
+	started := make(chan struct{}, 4)
+	if err := s.Every("refresh", time.Hour, func(ctx context.Context) {
+		atomic.AddInt32(&runs, 1)
+		started <- struct{}{}
+		<-release
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("boom", time.Hour, func(ctx context.Context) {
+		panic("manual boom")
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	if err := s.RunNow("refresh"); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("RunNow before Start: err = %v, want ErrNotRunning", err)
+	}
+
+	s.Start()
+	defer s.Stop()
+
+	if err := s.RunNow("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("RunNow(missing): err = %v, want ErrJobNotFound", err)
+	}
+	if err := s.RunNow("boom"); err != nil {
+		t.Fatalf("RunNow(boom): %v", err)
+	}
+
+	if err := s.RunNow("refresh"); err != nil {
+		t.Fatalf("RunNow: %v", err)
+	}
+	<-started
+	// Overlapping trigger is skipped while the first run is in flight.
+	if err := s.RunNow("refresh"); err != nil {
+		t.Fatalf("RunNow: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("runs = %d, want 1", got)
+	}
+}
+
+func TestSchedulerAddAfterStart(t *testing.T) {
+	s := New()
+	s.Start()
+
+	fired := make(chan context.Context, 1)
+	release := make(chan struct{})
+	var finished atomic.Bool
+	if err := s.Every("late-job", time.Second, func(ctx context.Context) {
+		select {
+		case fired <- ctx:
+		default:
+		}
+		<-release
+		finished.Store(true)
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	jobs := s.Jobs()
+	if len(jobs) != 1 || jobs[0].Name != "late-job" {
+		t.Fatalf("job not visible immediately: %+v", jobs)
+	}
+
+	var ctx context.Context
+	select {
+	case ctx = <-fired:
+	case <-time.After(3 * time.Second):
+		t.Fatal("job added after Start did not fire")
+	}
+	if ctx.Done() != s.jobContext().Done() || ctx.Err() != nil {
+		t.Fatalf("job should run with the live run context (err=%v)", ctx.Err())
+	}
+
+	stopped := s.Stop()
+	if ctx.Err() == nil {
+		t.Error("run context should be canceled by Stop")
+	}
+	select {
+	case <-stopped.Done():
+		t.Fatal("Stop should wait for the running job")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-stopped.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not finish after the job returned")
+	}
+	if !finished.Load() {
+		t.Error("stop context done before job finished")
+	}
+}
+
+func TestSchedulerJobRunTimes(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	s := New(WithLocation(loc))
+
+	ran := make(chan struct{}, 4)
+	if err := s.Every("tick", time.Second, func(ctx context.Context) {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	job, ok := s.JobByName("tick")
+	if !ok {
+		t.Fatal("JobByName(tick) not found")
+	}
+	if !job.NextRun.IsZero() || !job.PrevRun.IsZero() {
+		t.Errorf("run times before start = %v / %v, want zero", job.NextRun, job.PrevRun)
+	}
+	if _, ok := s.JobByName("missing"); ok {
+		t.Error("JobByName(missing) should not be found")
+	}
+
+	s.Start()
+	defer s.Stop()
+	select {
+	case <-ran:
+	case <-time.After(3 * time.Second):
+		t.Fatal("job did not run")
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	job, _ = s.JobByName("tick")
+	if job.NextRun.Location() != loc {
+		t.Errorf("NextRun = %v, want location %v", job.NextRun, loc)
+	}
+	if job.PrevRun.IsZero() || job.PrevRun.Location() != loc {
+		t.Errorf("PrevRun after run = %v, want non-zero in %v", job.PrevRun, loc)
+	}
+	if !job.NextRun.After(job.PrevRun) {
+		t.Errorf("NextRun %v should be after PrevRun %v", job.NextRun, job.PrevRun)
+	}
+}
+
+func TestSchedulerErrorHandler(t *testing.T) {
+	var mu sync.Mutex
+	got := map[string]error{}
+	s := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithErrorHandler(func(name string, err error) {
+			mu.Lock()
+			got[name] = err
+			mu.Unlock()
+		}),
+	)
+
+	errSync := errors.New("upstream unavailable")
+	if err := s.EveryE("sync", time.Hour, func(ctx context.Context) error { return errSync }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.CronE("ok", "0 * * * *", func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("boom", time.Hour, func(ctx context.Context) { panic("kaput") }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	for _, name := range []string{"sync", "ok", "boom"} {
+		s.execute(s.jobs[name], true)
+	}
+
+	if !errors.Is(got["sync"], errSync) {
+		t.Errorf("sync error = %v, want %v", got["sync"], errSync)
+	}
+	if _, ok := got["ok"]; ok {
+		t.Errorf("handler called for successful job: %v", got["ok"])
+	}
+	var pe *PanicError
+	if !errors.As(got["boom"], &pe) || pe.Value != "kaput" || len(pe.Stack) == 0 {
+		t.Errorf("boom error = %v, want *PanicError(kaput)", got["boom"])
+	}
+}
+
+func TestSchedulerJobTimeout(t *testing.T) {
+	var logs syncBuffer
+	s := New(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+	result := make(chan error, 1)
+	if err := s.Every("slow", time.Hour, func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+			result <- ctx.Err()
+		case <-time.After(2 * time.Second):
+			result <- nil
+		}
+	}, Timeout(50*time.Millisecond)); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	start := time.Now()
+	s.execute(s.jobs["slow"], true)
+	if err := <-result; !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("job ctx err = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("job ran %v, expected cancellation after ~50ms", elapsed)
+	}
+	// The warning is logged from the context's AfterFunc goroutine.
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(logs.String(), "job timed out") && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !strings.Contains(logs.String(), "job timed out") || !strings.Contains(logs.String(), "job_name=slow") {
+		t.Errorf("expected timeout warning, logs:\n%s", logs.String())
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes from log handlers.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSchedulerJobsConcurrentWithAddRemove(t *testing.T) {
+	s := New()
+	s.Start()
+	defer s.Stop()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				name := fmt.Sprintf("job-%d-%d", w, i)
+				if err := s.Every(name, time.Hour, func(ctx context.Context) {}); err != nil {
+					t.Errorf("schedule: %v", err)
+					return
+				}
+				s.Remove(name)
+			}
+		}(w)
+	}
+	for i := 0; i < 200; i++ {
+		for _, job := range s.Jobs() {
+			if job.Name == "" {
+				t.Fatal("empty job name")
+			}
+		}
+	}
+	wg.Wait()
+	if n := len(s.Jobs()); n != 0 {
+		t.Errorf("jobs left = %d, want 0", n)
+	}
+}
+
+func TestSchedulerReschedule(t *testing.T) {
+	s := New()
+	var runs int32
+	if err := s.Every("tick", time.Second, func(ctx context.Context) {
+		atomic.AddInt32(&runs, 1)
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	before, _ := s.JobByName("tick")
+
+	if err := s.Reschedule("tick", "not a cron expr"); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if err := s.Reschedule("missing", "* * * * *"); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("Reschedule(missing) err = %v", err)
+	}
+	after, _ := s.JobByName("tick")
+	if after.Schedule != before.Schedule || after.EntryID != before.EntryID {
+		t.Fatalf("failed reschedule changed the job: %+v -> %+v", before, after)
+	}
+
+	// The old cadence keeps running.
+	s.Start()
+	time.Sleep(1500 * time.Millisecond)
+	if n := atomic.LoadInt32(&runs); n < 1 {
+		t.Fatalf("runs = %d on old cadence, want >= 1", n)
+	}
+
+	if err := s.RescheduleEvery("tick", time.Hour); err != nil {
+		t.Fatalf("RescheduleEvery: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	n := atomic.LoadInt32(&runs)
+	time.Sleep(1500 * time.Millisecond)
+	<-s.Stop().Done()
+	if got := atomic.LoadInt32(&runs); got != n {
+		t.Errorf("job kept firing on the old cadence: %d -> %d", n, got)
+	}
+
+	job, _ := s.JobByName("tick")
+	if job.Schedule != "@every 1h0m0s" || len(s.cron.Entries()) != 1 {
+		t.Errorf("job = %+v, cron entries = %d", job, len(s.cron.Entries()))
+	}
+}
+
+func TestSchedulerSecondsPrecision(t *testing.T) {
+	noop := func(ctx context.Context) {}
+
+	def := New()
+	if err := def.Cron("five", "*/5 * * * *", noop); err != nil {
+		t.Errorf("default parser rejected 5-field expression: %v", err)
+	}
+	if err := def.Cron("six", "*/15 * * * * *", noop); err == nil {
+		t.Error("default parser should reject 6-field expression")
+	}
+
+	sec := New(WithSeconds())
+	if err := sec.Cron("six", "*/15 * * * * *", noop); err != nil {
+		t.Fatalf("WithSeconds rejected 6-field expression: %v", err)
+	}
+	if err := sec.Cron("five", "*/5 * * * *", noop); err != nil {
+		t.Errorf("WithSeconds rejected 5-field expression: %v", err)
+	}
+
+	entry := sec.cron.Entry(sec.jobs["six"].entryID)
+	from := time.Date(2026, 1, 1, 0, 0, 1, 0, time.UTC)
+	if next := entry.Schedule.Next(from); !next.Equal(from.Add(14 * time.Second)) {
+		t.Errorf("next run = %v, want %v", next, from.Add(14*time.Second))
+	}
+	five := sec.cron.Entry(sec.jobs["five"].entryID)
+	if next := five.Schedule.Next(from); !next.Equal(time.Date(2026, 1, 1, 0, 5, 0, 0, time.UTC)) {
+		t.Errorf("5-field next run under WithSeconds = %v", next)
+	}
+}
+
+func TestSchedulerDuplicateName(t *testing.T) {
+	s := New()
+	if err := s.Every("sync", time.Minute, func(ctx context.Context) {}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	err := s.Cron("sync", "0 * * * *", func(ctx context.Context) {})
+	if !errors.Is(err, ErrJobExists) {
+		t.Fatalf("err = %v, want ErrJobExists", err)
+	}
+	if got := s.jobs["sync"].schedule; got != "@every 1m0s" {
+		t.Errorf("schedule = %q, want original kept", got)
+	}
+	if n := len(s.cron.Entries()); n != 1 {
+		t.Errorf("cron entries = %d, want 1", n)
+	}
+}
+
+func TestSchedulerReplaceExisting(t *testing.T) {
+	s := New(WithReplaceExisting())
+	var oldRuns, newRuns atomic.Int32
+	if err := s.Every("sync", time.Minute, func(ctx context.Context) { oldRuns.Add(1) }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("sync", time.Minute, func(ctx context.Context) { newRuns.Add(1) }); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	if err := s.Cron("sync", "not a cron", func(ctx context.Context) { oldRuns.Add(1) }); err == nil {
+		t.Fatal("invalid replacement should fail")
+	}
+
+	entries := s.cron.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("cron entries = %d, want 1", len(entries))
+	}
+	for _, e := range entries {
+		e.Job.Run()
+	}
+	if oldRuns.Load() != 0 || newRuns.Load() != 1 {
+		t.Errorf("old runs = %d, new runs = %d; want 0, 1", oldRuns.Load(), newRuns.Load())
+	}
+}
+
+func TestSchedulerUpdateScheduleKeepsStats(t *testing.T) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err := s.Every("sync", time.Minute, func(ctx context.Context) {}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.execute(s.jobs["sync"], true)
+
+	if err := s.UpdateSchedule("sync", "bogus"); err == nil {
+		t.Fatal("bad expression should fail")
+	}
+	if got := s.jobs["sync"].schedule; got != "@every 1m0s" {
+		t.Errorf("schedule after bad update = %q", got)
+	}
+	if err := s.UpdateInterval("sync", time.Hour); err != nil {
+		t.Fatalf("UpdateInterval: %v", err)
+	}
+	if err := s.UpdateSchedule("missing", "0 * * * *"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("err = %v, want ErrJobNotFound", err)
+	}
+
+	job := s.jobs["sync"]
+	if job.schedule != "@every 1h0m0s" || job.interval != time.Hour || len(s.cron.Entries()) != 1 {
+		t.Errorf("job = %+v, cron entries = %d", job, len(s.cron.Entries()))
+	}
+	if st, _ := s.Stats("sync"); st.Runs != 1 {
+		t.Errorf("stats runs = %d, want 1 (kept across update)", st.Runs)
+	}
+}
+
+func TestSchedulerParseErrorNamesFormat(t *testing.T) {
+	noop := func(ctx context.Context) {}
+	err := New().Cron("six", "*/10 * * * * *", noop)
+	if err == nil || !strings.Contains(err.Error(), "5-field") || strings.Contains(err.Error(), "WithSeconds") {
+		t.Errorf("default error = %v, want it to name the 5-field format", err)
+	}
+	err = New(WithSeconds()).Cron("bad", "*/10 * *", noop)
+	if err == nil || !strings.Contains(err.Error(), "WithSeconds") {
+		t.Errorf("WithSeconds error = %v, want it to name the seconds format", err)
+	}
+}
+
+func TestSchedulerStopWait(t *testing.T) {
+	if err := New().StopWait(context.Background()); err != nil {
+		t.Fatalf("never-started StopWait = %v", err)
+	}
+
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	if err := s.Every("stubborn", time.Hour, func(ctx context.Context) {
+		close(started)
+		<-release // ignores ctx
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.Start()
+	if err := s.RunNow("stubborn"); err != nil {
+		t.Fatalf("RunNow: %v", err)
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := s.StopWait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "stubborn") {
+		t.Fatalf("StopWait = %v, want deadline error naming stubborn", err)
+	}
+
+	// A later StopWait still tracks the first Stop's drain.
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel2()
+	if err := s.StopWait(ctx2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second StopWait = %v, want deadline while job still runs", err)
+	}
+	close(release)
+	if err := s.StopWait(context.Background()); err != nil {
+		t.Fatalf("StopWait after release = %v", err)
+	}
+}
+
+func TestSchedulerStopWithTimeout(t *testing.T) {
+	start := time.Now()
+	if err := New().StopWithTimeout(time.Hour); err != nil || time.Since(start) > time.Second {
+		t.Fatalf("never-started StopWithTimeout = %v after %v", err, time.Since(start))
+	}
+
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	started := make(chan struct{})
+	if err := s.Every("slow", time.Hour, func(ctx context.Context) {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+	}); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.Start()
+	if err := s.RunNow("slow"); err != nil {
+		t.Fatalf("RunNow: %v", err)
+	}
+	<-started
+	if err := s.StopWithTimeout(20 * time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("StopWithTimeout = %v, want deadline exceeded", err)
+	}
+	if err := s.StopWithTimeout(2 * time.Second); err != nil {
+		t.Errorf("StopWithTimeout after job finished = %v", err)
+	}
+}
+
+func TestSchedulerReplaceStopsOldEntry(t *testing.T) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	var oldRuns, newRuns atomic.Int32
+	if err := s.Every("tick", time.Second, func(ctx context.Context) { oldRuns.Add(1) }); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.Every("tick", time.Second, func(ctx context.Context) {}); !errors.Is(err, ErrDuplicateJob) {
+		t.Fatalf("duplicate Every = %v, want ErrDuplicateJob", err)
+	}
+	s.Start()
+	defer s.Stop()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for oldRuns.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := s.Replace("tick", "@every 1s", func(ctx context.Context) { newRuns.Add(1) }); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	before := oldRuns.Load()
+	time.Sleep(2200 * time.Millisecond)
+
+	if got := oldRuns.Load(); got != before {
+		t.Errorf("old entry kept firing after Replace: %d -> %d", before, got)
+	}
+	if newRuns.Load() == 0 {
+		t.Error("replacement never ran")
+	}
+	if n := len(s.cron.Entries()); n != 1 {
+		t.Errorf("cron entries = %d, want 1", n)
+	}
+}
+
+func TestSchedulerJobLogsCarryJobFields(t *testing.T) {
+	var logs syncBuffer
+	s := New(WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	if err := s.CronE("sync", "@every 1m", func(ctx context.Context) error { return errors.New("boom") }); err != nil {
+		t.Fatal(err)
+	}
+	s.execute(s.jobs["sync"], true)
+	if err := s.Reschedule("sync", "@every 2m"); err != nil {
+		t.Fatal(err)
+	}
+	s.execute(s.jobs["sync"], true)
+
+	want := map[string]string{
+		`msg="job scheduled"`:   `schedule="@every 1m"`,
+		`msg="job failed"`:      `schedule="@every 2m"`,
+		`msg="job rescheduled"`: `schedule="@every 2m"`,
+	}
+	for msg, sched := range want {
+		found := false
+		for _, line := range strings.Split(logs.String(), "\n") {
+			found = found || strings.Contains(line, msg) && strings.Contains(line, "job_name=sync "+sched)
+		}
+		if !found {
+			t.Errorf("no %s line with job_name=sync %s:\n%s", msg, sched, logs.String())
+		}
+	}
+}
+
+func TestSchedulerEveryRejectsBadIntervals(t *testing.T) {
+	s := New()
+	for _, interval := range []time.Duration{0, -time.Minute, 500 * time.Millisecond} {
+		err := s.Every("bad", interval, func(ctx context.Context) {})
+		if !errors.Is(err, ErrInvalidInterval) || !strings.Contains(err.Error(), interval.String()) {
+			t.Errorf("Every(%s) err = %v, want ErrInvalidInterval naming the interval", interval, err)
+		}
+	}
+	if len(s.Jobs()) != 0 {
+		t.Errorf("rejected jobs were registered: %v", s.Jobs())
+	}
+	if err := s.Every("ok", time.Second, func(ctx context.Context) {}); err != nil {
+		t.Fatalf("Every(1s): %v", err)
+	}
+	if err := s.RescheduleEvery("ok", 0); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("RescheduleEvery(0) err = %v", err)
+	}
+
+	// cron rounds sub-second intervals up to 1s even with a seconds field.
+	if err := New(WithSeconds()).Every("fast", 500*time.Millisecond, func(ctx context.Context) {}); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("sub-second interval with WithSeconds: err = %v, want ErrInvalidInterval", err)
+	}
+}
+
+func TestSchedulerSetEnabled(t *testing.T) {
+	var buf syncBuffer
+	s := New(WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	runs := 0
+	if err := s.Cron("report", "0 9 * * *", func(ctx context.Context) { runs++ }); err != nil {
+		t.Fatal(err)
+	}
+	if s.SetEnabled("missing", false) {
+		t.Error("SetEnabled should report unknown jobs")
+	}
+	if job, _ := s.JobByName("report"); !job.Enabled {
+		t.Error("jobs should start enabled")
+	}
+
+	if !s.SetEnabled("report", false) {
+		t.Fatal("SetEnabled returned false")
+	}
+	job := s.jobs["report"]
+	s.execute(job, false)
+	s.execute(job, true)
+	if runs != 0 {
+		t.Errorf("disabled job ran %d times", runs)
+	}
+	view, _ := s.JobByName("report")
+	if view.Enabled || view.Stats.DisabledSkips != 2 || view.Stats.Skips != 0 {
+		t.Errorf("Job = %+v, want disabled with 2 disabled skips", view)
+	}
+	if !strings.Contains(buf.String(), "job disabled, skipping") {
+		t.Errorf("missing disabled log: %s", buf.String())
+	}
+
+	if err := s.Reschedule("report", "0 10 * * *"); err != nil {
+		t.Fatal(err)
+	}
+	if view, _ := s.JobByName("report"); view.Enabled {
+		t.Error("Reschedule should keep the job disabled")
+	}
+
+	s.SetEnabled("report", true)
+	s.execute(job, false)
+	if runs != 1 {
+		t.Errorf("runs = %d after re-enabling, want 1", runs)
+	}
+	if view, _ := s.JobByName("report"); !view.Enabled || view.Stats.DisabledSkips != 2 {
+		t.Errorf("Job = %+v", view)
+	}
+}
@@ -0,0 +1,64 @@
+package schedulertest_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ez-api/foundation/scheduler"
+	"github.com/ez-api/foundation/scheduler/schedulertest"
+)
+
+// quotaResetter is a service that registers its own job; it only depends on
+// scheduler.Interface.
+type quotaResetter struct {
+	resets int
+}
+
+func (q *quotaResetter) Register(s scheduler.Interface) error {
+	return s.Cron("quota-reset", "0 0 * * *", func(ctx context.Context) {
+		if ctx.Err() == nil {
+			q.resets++
+		}
+	}, scheduler.Timeout(time.Minute), scheduler.Tags("quota"))
+}
+
+func ExampleFake() {
+	fake := schedulertest.New()
+	svc := &quotaResetter{}
+	if err := svc.Register(fake); err != nil {
+		panic(err)
+	}
+	fake.Start()
+	defer fake.Stop()
+
+	_ = fake.Tick("quota-reset")
+	_ = fake.Tick("quota-reset")
+
+	reg := fake.Registrations()[0]
+	fmt.Println(reg.Name, reg.Schedule, reg.Settings.Timeout, reg.Settings.Tags)
+	fmt.Println("resets:", svc.resets)
+	job, _ := fake.JobByName("quota-reset")
+	fmt.Println("runs:", job.Stats.Runs)
+	// Output:
+	// quota-reset 0 0 * * * 1m0s [quota]
+	// resets: 2
+	// runs: 2
+}
+
+func ExampleFake_overlap() {
+	fake := schedulertest.New(schedulertest.WithSkipIfRunning())
+	_ = fake.Every("sync", time.Minute, func(ctx context.Context) {
+		// A tick arriving while the job runs is dropped, as with the real scheduler.
+		_ = fake.Tick("sync")
+	})
+	fake.Start()
+	_ = fake.Tick("sync")
+
+	for _, run := range fake.Runs() {
+		fmt.Printf("%s skipped=%q\n", run.Job, run.Skipped)
+	}
+	// Output:
+	// sync skipped="still_running"
+	// sync skipped=""
+}
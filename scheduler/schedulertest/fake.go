@@ -0,0 +1,412 @@
+// Package schedulertest provides a synchronous scheduler.Interface for unit tests.
+package schedulertest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ez-api/foundation/scheduler"
+	"github.com/robfig/cron/v3"
+)
+
+// Option configures a Fake.
+type Option func(*Fake)
+
+// WithBaseContext sets the parent of the context passed to jobs, like scheduler.WithBaseContext.
+func WithBaseContext(ctx context.Context) Option {
+	return func(f *Fake) {
+		f.baseCtx = ctx
+	}
+}
+
+// WithSkipIfRunning makes OverlapSkip the default policy, like scheduler.WithSkipIfRunning.
+func WithSkipIfRunning() Option {
+	return func(f *Fake) {
+		f.skipIfRunning = true
+	}
+}
+
+// WithSeconds accepts an optional leading seconds field, like scheduler.WithSeconds.
+func WithSeconds() Option {
+	return func(f *Fake) {
+		f.parser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	}
+}
+
+// Registration records one successful Every/Cron call.
+type Registration struct {
+	Name     string
+	Schedule string // "@every <interval>" for Every
+	Settings scheduler.JobSettings
+}
+
+// Run records one Tick or RunNow of a job.
+type Run struct {
+	Job      string
+	Manual   bool                 // triggered by RunNow
+	Err      error                // job error, or *scheduler.PanicError
+	Skipped  scheduler.SkipReason // set when the overlap policy dropped the run
+	Disabled bool                 // dropped because the job was disabled
+}
+
+// Fake is a scheduler.Interface that never fires on its own: jobs run synchronously,
+// in the caller's goroutine, when the test calls Tick, TickAll or RunNow. Schedules
+// are validated as by Scheduler, and of the job options Timeout (applied to the run
+// context), Overlap and MaxRuns are honoured; the rest are only recorded in
+// Registration.Settings. The zero value is not usable; call New.
+type Fake struct {
+	parser        cron.Parser
+	skipIfRunning bool
+	baseCtx       context.Context
+
+	mu            sync.Mutex
+	started       bool
+	runCtx        context.Context
+	runCancel     context.CancelFunc
+	jobs          map[string]*fakeJob
+	registrations []Registration
+	runs          []Run
+	inflight      sync.WaitGroup
+}
+
+type fakeJob struct {
+	Registration
+	fn       func(ctx context.Context) error
+	disabled bool
+	running  int
+	delay    sync.Mutex // held during runs under OverlapDelay
+	stats    scheduler.JobStats
+}
+
+var _ scheduler.Interface = (*Fake)(nil)
+
+// New returns a stopped Fake.
+func New(opts ...Option) *Fake {
+	f := &Fake{
+		parser:  cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor),
+		baseCtx: context.Background(),
+		jobs:    make(map[string]*fakeJob),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Every registers fn under "@every interval".
+func (f *Fake) Every(name string, interval time.Duration, fn func(ctx context.Context), opts ...scheduler.JobOption) error {
+	return f.EveryE(name, interval, noError(fn), opts...)
+}
+
+// EveryE is Every for job functions that return an error.
+func (f *Fake) EveryE(name string, interval time.Duration, fn func(ctx context.Context) error, opts ...scheduler.JobOption) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: must be positive, got %s", scheduler.ErrInvalidInterval, interval)
+	}
+	if interval < time.Second {
+		return fmt.Errorf("%w: must be at least 1s, got %s", scheduler.ErrInvalidInterval, interval)
+	}
+	return f.add(name, "@every "+interval.String(), fn, opts)
+}
+
+// Cron registers fn under the cron expression expr.
+func (f *Fake) Cron(name string, expr string, fn func(ctx context.Context), opts ...scheduler.JobOption) error {
+	return f.add(name, expr, noError(fn), opts)
+}
+
+// CronE is Cron for job functions that return an error.
+func (f *Fake) CronE(name string, expr string, fn func(ctx context.Context) error, opts ...scheduler.JobOption) error {
+	return f.add(name, expr, fn, opts)
+}
+
+func noError(fn func(ctx context.Context)) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		fn(ctx)
+		return nil
+	}
+}
+
+func (f *Fake) add(name, spec string, fn func(ctx context.Context) error, opts []scheduler.JobOption) error {
+	if _, err := f.parser.Parse(spec); err != nil {
+		return fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, exists := f.jobs[name]; exists {
+		return fmt.Errorf("%w: %s", scheduler.ErrJobExists, name)
+	}
+	reg := Registration{Name: name, Schedule: spec, Settings: scheduler.ResolveJobOptions(opts...)}
+	f.jobs[name] = &fakeJob{Registration: reg, fn: fn}
+	f.registrations = append(f.registrations, reg)
+	return nil
+}
+
+// Remove unregisters the named job; a run in progress finishes.
+func (f *Fake) Remove(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.jobs[name]; !ok {
+		return false
+	}
+	delete(f.jobs, name)
+	return true
+}
+
+// SetEnabled turns the named job on or off; disabled jobs record their ticks as
+// Run.Disabled and in JobStats.DisabledSkips.
+func (f *Fake) SetEnabled(name string, enabled bool) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job, ok := f.jobs[name]
+	if ok {
+		job.disabled = !enabled
+	}
+	return ok
+}
+
+// Tick runs the named job once, as a scheduled fire, and returns its error. It
+// returns scheduler.ErrNotRunning before Start and scheduler.ErrJobNotFound for
+// unknown names; a skipped run returns nil.
+func (f *Fake) Tick(name string) error {
+	job, ctx, err := f.lookup(name)
+	if err != nil {
+		return err
+	}
+	return f.run(ctx, job, false)
+}
+
+// TickAll ticks every registered job once, in name order, and joins their errors.
+func (f *Fake) TickAll() error {
+	f.mu.Lock()
+	if !f.started {
+		f.mu.Unlock()
+		return scheduler.ErrNotRunning
+	}
+	names := make([]string, 0, len(f.jobs))
+	for name := range f.jobs {
+		names = append(names, name)
+	}
+	f.mu.Unlock()
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		// A job removed by an earlier one (or by MaxRuns) is not ticked.
+		if err := f.Tick(name); err != nil && !errors.Is(err, scheduler.ErrJobNotFound) {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunNow runs the named job once. Unlike Scheduler.RunNow it returns only after the
+// run; the job's error is recorded in Runs, not returned.
+func (f *Fake) RunNow(name string) error {
+	job, ctx, err := f.lookup(name)
+	if err != nil {
+		return err
+	}
+	_ = f.run(ctx, job, true)
+	return nil
+}
+
+func (f *Fake) lookup(name string) (*fakeJob, context.Context, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.started {
+		return nil, nil, scheduler.ErrNotRunning
+	}
+	job, ok := f.jobs[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", scheduler.ErrJobNotFound, name)
+	}
+	f.inflight.Add(1)
+	return job, f.runCtx, nil
+}
+
+// run executes job under its overlap policy and records the outcome. lookup must
+// have counted it in f.inflight.
+func (f *Fake) run(ctx context.Context, job *fakeJob, manual bool) error {
+	defer f.inflight.Done()
+	rec := Run{Job: job.Name, Manual: manual}
+
+	f.mu.Lock()
+	if job.disabled {
+		job.stats.DisabledSkips++
+		rec.Disabled = true
+		f.runs = append(f.runs, rec)
+		f.mu.Unlock()
+		return nil
+	}
+	policy := f.overlapPolicy(job)
+	if policy == scheduler.OverlapSkip && job.running > 0 {
+		job.stats.Skips++
+		rec.Skipped = scheduler.SkipStillRunning
+		f.runs = append(f.runs, rec)
+		f.mu.Unlock()
+		return nil
+	}
+	job.running++
+	job.stats.Runs++
+	job.stats.Running = job.running
+	job.stats.LastStart = time.Now()
+	f.mu.Unlock()
+
+	if policy == scheduler.OverlapDelay {
+		job.delay.Lock()
+	}
+	if d := job.Settings.Timeout; d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+	start := time.Now()
+	err := call(ctx, job.fn)
+	if policy == scheduler.OverlapDelay {
+		job.delay.Unlock()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job.running--
+	job.stats.Running = job.running
+	job.stats.LastDuration = time.Since(start)
+	if err != nil {
+		job.stats.ConsecutiveFailures++
+		job.stats.LastError = err.Error()
+		job.stats.LastErrorAt = time.Now()
+		var pe *scheduler.PanicError
+		if errors.As(err, &pe) {
+			job.stats.Panics++
+			job.stats.LastPanicAt = job.stats.LastErrorAt
+		}
+	} else {
+		job.stats.Successes++
+		job.stats.ConsecutiveFailures = 0
+		job.stats.LastError = ""
+		if n := job.Settings.MaxRuns; n > 0 && job.stats.Successes >= uint64(n) && f.jobs[job.Name] == job {
+			delete(f.jobs, job.Name)
+		}
+	}
+	rec.Err = err
+	f.runs = append(f.runs, rec)
+	return err
+}
+
+func call(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &scheduler.PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn(ctx)
+}
+
+func (f *Fake) overlapPolicy(job *fakeJob) scheduler.OverlapPolicy {
+	switch {
+	case job.Settings.Overlap != "":
+		return job.Settings.Overlap
+	case f.skipIfRunning:
+		return scheduler.OverlapSkip
+	default:
+		return scheduler.OverlapAllow
+	}
+}
+
+// Jobs returns the registered jobs sorted by name. EntryID, NextRun and PrevRun are
+// always zero.
+func (f *Fake) Jobs() []scheduler.Job {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]scheduler.Job, 0, len(f.jobs))
+	for _, job := range f.jobs {
+		out = append(out, f.viewLocked(job))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// JobByName returns the named job.
+func (f *Fake) JobByName(name string) (scheduler.Job, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job, ok := f.jobs[name]
+	if !ok {
+		return scheduler.Job{}, false
+	}
+	return f.viewLocked(job), true
+}
+
+func (f *Fake) viewLocked(job *fakeJob) scheduler.Job {
+	remaining := -1
+	if n := job.Settings.MaxRuns; n > 0 {
+		remaining = max(n-int(job.stats.Successes), 0)
+	}
+	return scheduler.Job{
+		Name:          job.Name,
+		Schedule:      job.Schedule,
+		Tags:          append([]string(nil), job.Settings.Tags...),
+		Protected:     job.Settings.Protected,
+		Overlap:       f.overlapPolicy(job),
+		Enabled:       !job.disabled,
+		Until:         job.Settings.Until,
+		RemainingRuns: remaining,
+		Stats:         job.stats,
+	}
+}
+
+// Start allows Tick and RunNow and creates a fresh run context.
+func (f *Fake) Start() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.started {
+		return
+	}
+	f.runCtx, f.runCancel = context.WithCancel(f.baseCtx)
+	f.started = true
+}
+
+// Stop cancels the run context. The returned context is done once runs in progress
+// in other goroutines have returned.
+func (f *Fake) Stop() context.Context {
+	f.mu.Lock()
+	if f.started {
+		f.started = false
+		f.runCancel()
+	}
+	f.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		f.inflight.Wait()
+		cancel()
+	}()
+	return ctx
+}
+
+// Running reports whether the Fake has been started and not stopped.
+func (f *Fake) Running() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.started
+}
+
+// Registrations returns every successful registration in call order, including jobs
+// since removed.
+func (f *Fake) Registrations() []Registration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Registration(nil), f.registrations...)
+}
+
+// Runs returns every recorded Tick and RunNow in completion order.
+func (f *Fake) Runs() []Run {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Run(nil), f.runs...)
+}
@@ -0,0 +1,143 @@
+package schedulertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ez-api/foundation/scheduler"
+)
+
+func TestFakeRegistration(t *testing.T) {
+	f := New()
+	noop := func(ctx context.Context) {}
+
+	tests := []struct {
+		name    string
+		add     func() error
+		wantErr bool
+		wantIs  error
+	}{
+		{"every", func() error { return f.Every("a", time.Minute, noop) }, false, nil},
+		{"duplicate", func() error { return f.Every("a", time.Hour, noop) }, true, scheduler.ErrJobExists},
+		{"sub-second", func() error { return f.Every("b", 100*time.Millisecond, noop) }, true, scheduler.ErrInvalidInterval},
+		{"zero interval", func() error { return f.Every("b", 0, noop) }, true, scheduler.ErrInvalidInterval},
+		{"cron", func() error { return f.Cron("c", "*/5 * * * *", noop) }, false, nil},
+		{"seconds cron", func() error { return f.Cron("d", "*/5 * * * * *", noop) }, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.add()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Fatalf("error = %v, want %v", err, tt.wantIs)
+			}
+		})
+	}
+
+	if regs := f.Registrations(); len(regs) != 2 || regs[0].Schedule != "@every 1m0s" || regs[1].Name != "c" {
+		t.Errorf("Registrations = %+v", regs)
+	}
+	if err := New(WithSeconds()).Cron("fast", "*/5 * * * * *", noop); err != nil {
+		t.Errorf("WithSeconds should accept a seconds field: %v", err)
+	}
+}
+
+func TestFakeTick(t *testing.T) {
+	f := New()
+	var deadline time.Time
+	boom := errors.New("boom")
+	calls := 0
+	if err := f.EveryE("job", time.Minute, func(ctx context.Context) error {
+		calls++
+		deadline, _ = ctx.Deadline()
+		switch calls {
+		case 2:
+			return boom
+		case 3:
+			panic("kaput")
+		}
+		return nil
+	}, scheduler.Timeout(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Tick("job"); !errors.Is(err, scheduler.ErrNotRunning) {
+		t.Fatalf("Tick before Start = %v", err)
+	}
+	f.Start()
+	if err := f.Tick("missing"); !errors.Is(err, scheduler.ErrJobNotFound) {
+		t.Fatalf("Tick(missing) = %v", err)
+	}
+
+	if err := f.Tick("job"); err != nil {
+		t.Fatal(err)
+	}
+	if time.Until(deadline) <= 0 || time.Until(deadline) > time.Hour {
+		t.Errorf("Timeout not applied to run context: deadline %v", deadline)
+	}
+	if err := f.Tick("job"); !errors.Is(err, boom) {
+		t.Errorf("Tick = %v, want %v", err, boom)
+	}
+	var pe *scheduler.PanicError
+	if err := f.RunNow("job"); err != nil {
+		t.Fatal(err)
+	}
+	runs := f.Runs()
+	if len(runs) != 3 || !runs[2].Manual || !errors.As(runs[2].Err, &pe) || pe.Value != "kaput" {
+		t.Fatalf("Runs = %+v", runs)
+	}
+
+	job, _ := f.JobByName("job")
+	st := job.Stats
+	if st.Runs != 3 || st.Successes != 1 || st.Panics != 1 || st.ConsecutiveFailures != 2 || st.LastError != "job panicked: kaput" {
+		t.Errorf("Stats = %+v", st)
+	}
+
+	f.SetEnabled("job", false)
+	if err := f.Tick("job"); err != nil || calls != 3 {
+		t.Errorf("disabled Tick = %v, calls %d", err, calls)
+	}
+	if job, _ := f.JobByName("job"); job.Enabled || job.Stats.DisabledSkips != 1 {
+		t.Errorf("Job = %+v", job)
+	}
+
+	done := f.Stop()
+	select {
+	case <-done.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not finish")
+	}
+	if f.Running() {
+		t.Error("Running after Stop")
+	}
+}
+
+func TestFakeTickAllAndMaxRuns(t *testing.T) {
+	f := New()
+	var order []string
+	record := func(name string) func(ctx context.Context) {
+		return func(ctx context.Context) { order = append(order, name) }
+	}
+	_ = f.Cron("b", "@hourly", record("b"))
+	_ = f.Cron("a", "@daily", record("a"), scheduler.MaxRuns(1))
+	_ = f.CronE("c", "@daily", func(ctx context.Context) error { return errors.New("down") })
+	f.Start()
+
+	if err := f.TickAll(); err == nil || err.Error() != "c: down" {
+		t.Errorf("TickAll = %v", err)
+	}
+	_ = f.TickAll()
+	if got := len(order); got != 3 || order[0] != "a" || order[1] != "b" || order[2] != "b" {
+		t.Errorf("order = %v, want [a b b]", order)
+	}
+	if _, ok := f.JobByName("a"); ok {
+		t.Error("job a should be removed after MaxRuns(1)")
+	}
+	if jobs := f.Jobs(); len(jobs) != 2 || jobs[0].Name != "b" || jobs[0].RemainingRuns != -1 {
+		t.Errorf("Jobs = %+v", jobs)
+	}
+}
@@ -0,0 +1,36 @@
+package scheduler
+
+import "sync"
+
+// flightGroup tracks in-flight executions per singleflight key.
+// It has its own lock so coalescing never contends with the scheduler mutex.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]chan struct{}
+}
+
+// acquire claims key for the caller. If key is free it returns a release func and true.
+// Otherwise it returns false, after waiting for the in-flight run to finish when wait is set.
+func (g *flightGroup) acquire(key string, wait bool) (func(), bool) {
+	g.mu.Lock()
+	if done, ok := g.flights[key]; ok {
+		g.mu.Unlock()
+		if wait {
+			<-done
+		}
+		return nil, false
+	}
+	if g.flights == nil {
+		g.flights = make(map[string]chan struct{})
+	}
+	done := make(chan struct{})
+	g.flights[key] = done
+	g.mu.Unlock()
+
+	return func() {
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(done)
+	}, true
+}
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// JobStats summarizes a job's executions, scheduled and manual alike. Runs that were
+// skipped (see SkipReason) are counted in Skips only.
+type JobStats struct {
+	Runs                uint64
+	Successes           uint64
+	Panics              uint64 // failed runs whose error is a *PanicError
+	Skips               uint64
+	DisabledSkips       uint64 // fires dropped while the job was disabled; not in Skips
+	Running             int    // runs currently in progress
+	ConsecutiveFailures int
+	LastStart           time.Time
+	LastDuration        time.Duration
+	LastError           string        // empty if the last finished run succeeded
+	LastErrorAt         time.Time     // end of the last failed run, panics included
+	LastPanicAt         time.Time     // end of the last panicked run
+	LastDrift           time.Duration // start delay of the last scheduled fire; see WithMaxDrift
+}
+
+// statsRecorder accumulates JobStats; it is safe for overlapping runs of one job.
+type statsRecorder struct {
+	mu    sync.Mutex
+	stats JobStats
+}
+
+func (r *statsRecorder) started(at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.Runs++
+	r.stats.Running++
+	r.stats.LastStart = at
+}
+
+func (r *statsRecorder) finished(at time.Time, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.Running--
+	r.stats.LastDuration = d
+	if err != nil {
+		r.stats.ConsecutiveFailures++
+		r.stats.LastError = err.Error()
+		r.stats.LastErrorAt = at
+		var pe *PanicError
+		if errors.As(err, &pe) {
+			r.stats.Panics++
+			r.stats.LastPanicAt = at
+		}
+		return
+	}
+	r.stats.Successes++
+	r.stats.ConsecutiveFailures = 0
+	r.stats.LastError = ""
+}
+
+func (r *statsRecorder) skipped() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.Skips++
+}
+
+func (r *statsRecorder) disabledSkip() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.DisabledSkips++
+}
+
+func (r *statsRecorder) drifted(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.LastDrift = d
+}
+
+func (r *statsRecorder) snapshot() JobStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// Stats returns the named job's run statistics.
+func (s *Scheduler) Stats(name string) (JobStats, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[name]
+	if !ok {
+		return JobStats{}, false
+	}
+	return job.stats.snapshot(), true
+}
+
+// AllStats returns the run statistics of every registered job, keyed by name.
+// It is safe to call while jobs run.
+func (s *Scheduler) AllStats() map[string]JobStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]JobStats, len(s.jobs))
+	for name, job := range s.jobs {
+		out[name] = job.stats.snapshot()
+	}
+	return out
+}
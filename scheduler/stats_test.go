@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchedulerStats(t *testing.T) {
+	clock := &fakeClock{wall: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}
+	s := New(WithClock(clock), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+	fail := true
+	if err := s.EveryE("sync", time.Hour, func(ctx context.Context) error {
+		clock.mono += 250 * time.Millisecond
+		if fail {
+			return errors.New("upstream 503")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Every("boom", time.Hour, func(ctx context.Context) { panic("bad state") }); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := s.Stats("missing"); ok {
+		t.Error("Stats(missing) should not be found")
+	}
+
+	s.execute(s.jobs["sync"], true)
+	s.execute(s.jobs["sync"], true)
+	st, _ := s.Stats("sync")
+	if st.Runs != 2 || st.ConsecutiveFailures != 2 || st.LastError != "upstream 503" || st.LastDuration != 250*time.Millisecond || !st.LastStart.Equal(clock.wall) {
+		t.Errorf("after failures: %+v", st)
+	}
+
+	fail = false
+	s.execute(s.jobs["sync"], true)
+	st, _ = s.Stats("sync")
+	if st.Runs != 3 || st.ConsecutiveFailures != 0 || st.LastError != "" || st.Running != 0 {
+		t.Errorf("after success: %+v", st)
+	}
+
+	s.execute(s.jobs["boom"], true)
+	st, _ = s.Stats("boom")
+	if st.Runs != 1 || st.ConsecutiveFailures != 1 || st.LastError != "job panicked: bad state" {
+		t.Errorf("after panic: %+v", st)
+	}
+
+	if err := s.Every("skipper", time.Hour, func(ctx context.Context) {}, Overlap(OverlapSkip)); err != nil {
+		t.Fatal(err)
+	}
+	s.jobs["skipper"].running.Store(true)
+	s.execute(s.jobs["skipper"], true)
+
+	all := s.AllStats()
+	if len(all) != 3 {
+		t.Fatalf("AllStats = %v, want 3 jobs", all)
+	}
+	if st := all["sync"]; st.Successes != 1 || st.Panics != 0 || !st.LastErrorAt.Equal(clock.wall) || !st.LastPanicAt.IsZero() {
+		t.Errorf("AllStats[sync] = %+v", st)
+	}
+	if st := all["boom"]; st.Successes != 0 || st.Panics != 1 || !st.LastPanicAt.Equal(clock.wall) || !st.LastErrorAt.Equal(clock.wall) {
+		t.Errorf("AllStats[boom] = %+v", st)
+	}
+	if st := all["skipper"]; st.Skips != 1 || st.Runs != 0 {
+		t.Errorf("AllStats[skipper] = %+v", st)
+	}
+
+	var jobStats JobStats
+	for _, job := range s.Jobs() {
+		if job.Name == "sync" {
+			jobStats = job.Stats
+		}
+	}
+	if jobStats.Runs != 3 {
+		t.Errorf("Jobs() summary = %+v", jobStats)
+	}
+}
+
+func TestSchedulerStatsConcurrentRuns(t *testing.T) {
+	s := New()
+	release := make(chan struct{})
+	if err := s.Every("overlap", time.Hour, func(ctx context.Context) { <-release }); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.execute(s.jobs["overlap"], true)
+		}()
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		st, _ := s.Stats("overlap")
+		if st.Running == 8 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("running = %d, want 8", st.Running)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	st, _ := s.Stats("overlap")
+	if st.Runs != 8 || st.Running != 0 {
+		t.Errorf("stats = %+v", st)
+	}
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status is a JSON-serializable snapshot of the scheduler, served to dashboards.
+// Its wire format is pinned by contract.SchedulerStatusJSON.
+type Status struct {
+	Running bool        `json:"running"`
+	Jobs    []JobStatus `json:"jobs"`
+}
+
+// JobStatus describes a single scheduled job in a Status snapshot.
+type JobStatus struct {
+	Name        string     `json:"name"`
+	Schedule    string     `json:"schedule"`
+	NextRun     time.Time  `json:"next_run"`
+	PrevRun     *time.Time `json:"prev_run,omitempty"`
+	LastDriftMS int64      `json:"last_drift_ms,omitempty"` // how late the last scheduled fire started
+	// Until and RemainingRuns are set for jobs registered with Until and MaxRuns.
+	Until         *time.Time `json:"until,omitempty"`
+	RemainingRuns *int       `json:"remaining_runs,omitempty"`
+}
+
+// Status returns a snapshot of the scheduler and its jobs, sorted by job name.
+func (s *Scheduler) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st := Status{Running: s.started, Jobs: make([]JobStatus, 0, len(s.jobs))}
+	for _, job := range s.jobs {
+		js := JobStatus{Name: job.name, Schedule: job.schedule}
+		next, prev := s.runTimesLocked(job, true)
+		js.NextRun = next
+		if !prev.IsZero() {
+			js.PrevRun = &prev
+		}
+		js.LastDriftMS = job.stats.snapshot().LastDrift.Milliseconds()
+		if until := job.cfg.until; !until.IsZero() {
+			until = until.In(s.location)
+			js.Until = &until
+		}
+		if left := remainingRuns(job); left >= 0 {
+			js.RemainingRuns = &left
+		}
+		st.Jobs = append(st.Jobs, js)
+	}
+	sort.Slice(st.Jobs, func(i, j int) bool { return st.Jobs[i].Name < st.Jobs[j].Name })
+	return st
+}
+
+// Validate checks the fields consumers of the status document rely on: a non-nil job
+// list, and a name, schedule and next run per job.
+func (s Status) Validate() error {
+	if s.Jobs == nil {
+		return errors.New("jobs required")
+	}
+	for i, job := range s.Jobs {
+		if strings.TrimSpace(job.Name) == "" {
+			return fmt.Errorf("jobs[%d]: name required", i)
+		}
+		if strings.TrimSpace(job.Schedule) == "" {
+			return fmt.Errorf("jobs[%d] (%s): schedule required", i, job.Name)
+		}
+		if job.NextRun.IsZero() {
+			return fmt.Errorf("jobs[%d] (%s): next_run required", i, job.Name)
+		}
+		if job.RemainingRuns != nil && *job.RemainingRuns < 0 {
+			return fmt.Errorf("jobs[%d] (%s): remaining_runs must be >= 0", i, job.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package tokenhash
+
+import (
+	"math"
+	"strings"
+)
+
+// TokenKind is the likely origin of a credential, inferred from its shape only.
+type TokenKind string
+
+const (
+	// KindUnknown is any token Classify does not recognize.
+	KindUnknown TokenKind = "unknown"
+	// KindOpenAI is an OpenAI API key ("sk-...", other than Anthropic's).
+	KindOpenAI TokenKind = "openai"
+	// KindAnthropic is an Anthropic API key ("sk-ant-...").
+	KindAnthropic TokenKind = "anthropic"
+	// KindGoogle is a Google API key ("AIza" plus 35 key characters).
+	KindGoogle TokenKind = "google"
+	// KindEZ is a key issued by this gateway ("ez_...").
+	KindEZ TokenKind = "ez"
+	// KindJWT is a compact JWS: three base64url segments with a JSON header.
+	KindJWT TokenKind = "jwt"
+)
+
+const (
+	prefixAnthropic = "sk-ant-"
+	prefixOpenAI    = "sk-"
+	prefixGoogle    = "AIza"
+	prefixEZ        = "ez_"
+
+	// googleAPIKeyLen is the fixed length of a Google API key ("AIza" + 35 chars).
+	googleAPIKeyLen = 39
+
+	// minSecretLen is the shortest string LooksLikeSecret accepts, prefixed or not.
+	minSecretLen = 20
+)
+
+// Classify guesses what kind of credential token is by prefix and structure.
+// It never logs or retains the input.
+func Classify(token string) TokenKind {
+	token = strings.TrimSpace(token)
+	switch {
+	case token == "":
+		return KindUnknown
+	case strings.HasPrefix(token, prefixAnthropic) && len(token) > len(prefixAnthropic):
+		return KindAnthropic
+	case strings.HasPrefix(token, prefixOpenAI) && len(token) > len(prefixOpenAI):
+		return KindOpenAI
+	case strings.HasPrefix(token, prefixGoogle) && len(token) == googleAPIKeyLen && isKeyCharset(token):
+		return KindGoogle
+	case strings.HasPrefix(token, prefixEZ) && len(token) > len(prefixEZ):
+		return KindEZ
+	case isJWT(token):
+		return KindJWT
+	default:
+		return KindUnknown
+	}
+}
+
+// LooksLikeSecret reports whether s is plausibly a credential: long enough, a single
+// word of key-like characters, and with enough character entropy to not be prose.
+// Tokens Classify recognizes pass the same length check with a lower entropy bar, so
+// a bare prefix such as "sk-x" is not a secret. It is a heuristic intended for log
+// redaction, so it errs on the side of true.
+func LooksLikeSecret(s string) bool {
+	s = strings.TrimSpace(s)
+	if len(s) < minSecretLen || !isKeyCharset(s) {
+		return false
+	}
+	if Classify(s) != KindUnknown {
+		return shannonEntropy(s) >= 3.0
+	}
+	return shannonEntropy(s) >= 3.5
+}
+
+func isJWT(token string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	for i, p := range parts {
+		// The signature segment may be empty for unsecured ("alg":"none") tokens.
+		if p == "" && i < 2 {
+			return false
+		}
+		if !isBase64URL(p) {
+			return false
+		}
+	}
+	// Header is base64url("{\"...") which always begins with "eyJ".
+	return strings.HasPrefix(parts[0], "eyJ")
+}
+
+func isBase64URL(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isKeyCharset(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == '+', c == '/', c == '=':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func shannonEntropy(s string) float64 {
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+	n := float64(len(s))
+	var h float64
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}